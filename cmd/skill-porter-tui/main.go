@@ -11,6 +11,29 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "undo" {
+		if err := runUndo(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Undo error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "roundtrip" {
+		if err := runRoundtrip(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Round trip error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		if err := runReport(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Report error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
@@ -38,8 +61,8 @@ func main() {
 	}
 
 	if m, ok := finalModel.(ui.Model); ok {
-		if m.FailCount > 0 {
-			os.Exit(1)
+		if code := m.ExitCode(); code != 0 {
+			os.Exit(code)
 		}
 	} else {
 		logger.Error("Could not cast final model", nil)
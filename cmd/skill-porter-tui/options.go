@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/settings"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+)
+
+// optionFlags are the flags of the report and roundtrip subcommands that
+// configure a conversion, as the TUI's flags of the same names do.
+type optionFlags struct {
+	tools         *string
+	settingsRules *string
+}
+
+func addOptionFlags(fs *flag.FlagSet) optionFlags {
+	return optionFlags{
+		tools:         fs.String("tools", os.Getenv("SKILL_PORTER_TOOLS"), "Tool catalog that extends the built-in one"),
+		settingsRules: fs.String("settings-rules", os.Getenv("SKILL_PORTER_SETTINGS_RULES"), "Settings inference rules tried before the built-in ones"),
+	}
+}
+
+// options loads the files the flags name.
+func (f optionFlags) options() (conversion.Options, error) {
+	catalog, err := tools.Resolve(*f.tools)
+	if err != nil {
+		return conversion.Options{}, fmt.Errorf("could not load tool catalog: %w", err)
+	}
+	rules, err := settings.Resolve(*f.settingsRules)
+	if err != nil {
+		return conversion.Options{}, fmt.Errorf("could not load settings rules: %w", err)
+	}
+	return conversion.Options{Tools: catalog, Settings: rules}, nil
+}
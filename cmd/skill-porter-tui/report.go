@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/discovery"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// runReport implements `skill-porter-tui report [--target t] [--split-plugins] <skill-path>...`,
+// planning the conversion of each skill without writing anything and listing
+// the source fields it would drop.
+func runReport(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("skill-porter-tui report", flag.ContinueOnError)
+	targetStr := fs.String("target", "auto", "Conversion target (Gemini, Claude, Universal, Auto)")
+	optFlags := addOptionFlags(fs)
+	split := fs.Bool("split-plugins", false, "Convert each skill of a Claude plugin into its own Gemini extension")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var target domain.ConversionTarget
+	switch strings.ToLower(*targetStr) {
+	case "gemini":
+		target = domain.TargetGemini
+	case "claude":
+		target = domain.TargetClaude
+	case "universal":
+		target = domain.TargetUniversal
+	case "auto":
+		target = domain.TargetAuto
+	default:
+		return fmt.Errorf("invalid target: %s", *targetStr)
+	}
+	opts, err := optFlags.options()
+	if err != nil {
+		return err
+	}
+	opts.SplitPlugins = *split
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: skill-porter-tui report [--target t] <skill-path>...")
+	}
+
+	for _, path := range fs.Args() {
+		skillTarget := target
+		if skillTarget == domain.TargetAuto {
+			// Convert to the other platform, as the TUI does
+			det, err := discovery.Detect(path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			skillTarget = discovery.AutoTarget(det.Platform)
+		}
+		result, err := conversion.PlanConversion(path, skillTarget, "", opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Fprint(stdout, conversion.FormatLossReport(path, skillTarget, result))
+	}
+	return nil
+}
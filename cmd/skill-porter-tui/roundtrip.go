@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+)
+
+// runRoundtrip implements `skill-porter-tui roundtrip [--max-loss n] <skill-path>...`,
+// converting each skill to the other platform and back and reporting what
+// was lost. It fails when any skill scores above the maximum loss.
+func runRoundtrip(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("skill-porter-tui roundtrip", flag.ContinueOnError)
+	maxLoss := fs.Int("max-loss", 100, "Fail when a skill's loss score exceeds this (0-100)")
+	optFlags := addOptionFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts, err := optFlags.options()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: skill-porter-tui roundtrip [--max-loss n] <skill-path>...")
+	}
+
+	var over []string
+	for _, path := range fs.Args() {
+		report, err := conversion.Roundtrip(path, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Fprint(stdout, conversion.FormatRoundtrip(report))
+		if report.Score > *maxLoss {
+			over = append(over, path)
+		}
+	}
+	if len(over) > 0 {
+		return fmt.Errorf("%d skill(s) exceed the maximum loss score of %d: %v", len(over), *maxLoss, over)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+)
+
+// runUndo implements `skill-porter-tui undo [--backups dir] <skill-path>...`,
+// restoring each skill from its most recent in-place conversion backup.
+func runUndo(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("skill-porter-tui undo", flag.ContinueOnError)
+	backupDir := fs.String("backups", conversion.DefaultBackupRoot(), "Directory holding conversion backups")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: skill-porter-tui undo [--backups dir] <skill-path>...")
+	}
+
+	for _, path := range fs.Args() {
+		snap, err := conversion.FindLatestSnapshot(*backupDir, path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := snap.Restore(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Fprintf(stdout, "Restored %s to its state before the conversion at %s (%d file(s))\n",
+			snap.SkillPath, snap.Created.Format("2006-01-02 15:04:05"), len(snap.Files))
+	}
+	return nil
+}
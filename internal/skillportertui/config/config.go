@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/settings"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
 )
 
 type AppConfig struct {
@@ -17,6 +19,13 @@ type AppConfig struct {
 	OutBaseDir      string
 	AutoConvertMode bool
 	Debug           bool
+	Backend         domain.ConversionBackend
+	DryRun          bool
+	BackupDir       string // Where in-place conversions are backed up for undo
+	Tools           *tools.Catalog
+	SettingsRules   *settings.Rules // Rules for inferring Gemini extension settings
+	SplitPlugins    bool            // Convert each skill of a Claude plugin into its own extension
+	FailOnWarning   bool            // Exit with status 2 when a conversion left warnings
 }
 
 func Load(args []string) (*AppConfig, error) {
@@ -30,11 +39,20 @@ func Load(args []string) (*AppConfig, error) {
 	var rootFlag string
 	fs.StringVar(&rootFlag, "root", "", "Root directory to scan for skills (default: current directory)")
 	fs.BoolVar(&cfg.RecursiveMode, "recursive", true, "Scan recursively")
-	targetStr := fs.String("target", defaultTarget, "Default conversion target (Gemini, Claude, Auto)")
+	targetStr := fs.String("target", defaultTarget, "Default conversion target (Gemini, Claude, Universal, Auto)")
 	var outFlag string
 	fs.StringVar(&outFlag, "out", "", "Base directory for output (default: in-place)")
 	fs.BoolVar(&cfg.AutoConvertMode, "auto", false, "Enable auto-convert mode")
 	fs.BoolVar(&cfg.Debug, "debug", false, "Enable debug logging")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "Preview conversions without writing any files")
+	fs.BoolVar(&cfg.FailOnWarning, "fail-on-warning", false, "Exit with status 2 when a conversion left warnings to review")
+	fs.BoolVar(&cfg.SplitPlugins, "split-plugins", false, "Convert each skill of a Claude plugin into its own Gemini extension")
+	fs.StringVar(&cfg.BackupDir, "backups", "", "Directory for undo backups of in-place conversions (default: user cache dir)")
+	var toolsFlag string
+	fs.StringVar(&toolsFlag, "tools", "", "Tool catalog that extends the built-in one (default: <user config dir>/skill-porter/tools.yaml)")
+	var settingsFlag string
+	fs.StringVar(&settingsFlag, "settings-rules", "", "Settings inference rules tried before the built-in ones (default: <user config dir>/skill-porter/settings.yaml)")
+	backendStr := fs.String("backend", string(domain.BackendNative), "Conversion backend (native, subprocess)")
 
 	if err := fs.Parse(args); err != nil {
 		return nil, err
@@ -59,12 +77,41 @@ func Load(args []string) (*AppConfig, error) {
 		cfg.DefaultTarget = domain.TargetGemini
 	case "claude":
 		cfg.DefaultTarget = domain.TargetClaude
+	case "universal":
+		cfg.DefaultTarget = domain.TargetUniversal
 	case "auto":
 		cfg.DefaultTarget = domain.TargetAuto
 	default:
 		return nil, fmt.Errorf("invalid target: %s", *targetStr)
 	}
 
+	switch strings.ToLower(*backendStr) {
+	case "native":
+		cfg.Backend = domain.BackendNative
+	case "subprocess":
+		cfg.Backend = domain.BackendSubprocess
+	default:
+		return nil, fmt.Errorf("invalid backend: %s", *backendStr)
+	}
+
+	if toolsFlag == "" {
+		toolsFlag = os.Getenv("SKILL_PORTER_TOOLS")
+	}
+	catalog, err := tools.Resolve(toolsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not load tool catalog: %v", err)
+	}
+	cfg.Tools = catalog
+
+	if settingsFlag == "" {
+		settingsFlag = os.Getenv("SKILL_PORTER_SETTINGS_RULES")
+	}
+	rules, err := settings.Resolve(settingsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not load settings rules: %v", err)
+	}
+	cfg.SettingsRules = rules
+
 	if info, err := os.Stat(cfg.ScanRoot); err != nil || !info.IsDir() {
 		return nil, fmt.Errorf("invalid scan root: %s", cfg.ScanRoot)
 	}
@@ -59,6 +59,24 @@ func TestLoad_Validation(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for invalid target, got nil")
 	}
+
+	args = []string{"-backend", "invalidBackend"}
+	_, err = Load(args)
+	if err == nil {
+		t.Error("Expected error for invalid backend, got nil")
+	}
+
+	args = []string{"-tools", "/non/existent/tools.yaml"}
+	_, err = Load(args)
+	if err == nil {
+		t.Error("Expected error for missing tool catalog, got nil")
+	}
+
+	args = []string{"-settings-rules", "/non/existent/settings.yaml"}
+	_, err = Load(args)
+	if err == nil {
+		t.Error("Expected error for missing settings rules, got nil")
+	}
 }
 
 func TestLoad_Defaults(t *testing.T) {
@@ -72,6 +90,22 @@ func TestLoad_Defaults(t *testing.T) {
 	if cfg.DefaultTarget != domain.TargetAuto {
 		t.Errorf("Expected default target Auto, got %s", cfg.DefaultTarget)
 	}
+
+	if cfg.Backend != domain.BackendNative {
+		t.Errorf("Expected default backend native, got %s", cfg.Backend)
+	}
+
+	if cfg.DryRun {
+		t.Error("Expected dry run to be off by default")
+	}
+
+	if cfg.FailOnWarning {
+		t.Error("Expected warnings not to change the exit status by default")
+	}
+
+	if cfg.SplitPlugins {
+		t.Error("Expected plugins to convert to a single extension by default")
+	}
 	
 	cwd, _ := os.Getwd()
 	absCwd, _ := filepath.Abs(cwd)
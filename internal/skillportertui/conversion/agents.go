@@ -0,0 +1,206 @@
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// Claude Code subagents, .claude/agents/<name>.md, have no Gemini
+// equivalent. Each becomes a Gemini command in the agents namespace,
+// commands/agents/<name>.toml (run as /agents:<name>), whose prompt is the
+// agent's full system prompt followed by the user's query, marked with
+// claudeSubagentKey. Converting back turns only the marked commands into
+// subagents again; other commands in the namespace stay slash commands.
+
+const (
+	claudeAgentsDir   = ".claude/agents"
+	geminiAgentsDir   = "agents" // Under commands/
+	agentQuerySuffix  = "\n\nUser Query: {{args}}"
+	claudeSubagentKey = "claude-subagent" // TOML key, ignored by Gemini, marking a converted subagent
+)
+
+// agentNameRe matches the subagent names Claude Code accepts: lowercase
+// letters, digits and hyphens. The name becomes a file name, so nothing else
+// is used.
+var agentNameRe = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// claudeAgentKeys are the subagent frontmatter keys a Gemini command can
+// express. Every other key, such as tools or model, is lost.
+var claudeAgentKeys = []string{"name", "description"}
+
+// lossyAgentReasons explains why the subagent keys Claude Code defines are
+// lost in a Gemini command.
+var lossyAgentReasons = map[string]string{
+	"tools": "Gemini commands cannot restrict tools; the agent runs with the extension's tools",
+	"model": "Gemini commands cannot choose a model; the agent runs on the session's model",
+	"color": "Gemini commands have no display color",
+}
+
+// claudeAgent is a subagent definition file.
+type claudeAgent struct {
+	File        string // Relative to the skill
+	Name        string
+	Description string
+	Prompt      string      // The system prompt: the body of the file
+	Lossy       []yamlEntry // Frontmatter a Gemini command cannot express
+}
+
+// readClaudeAgents reads the *.md files in agentsDir, relative to dir: a
+// skill's .claude/agents or a plugin's agents. The file name is used when the
+// frontmatter has no name or one Claude Code does not accept, which is
+// reported in the returned warnings.
+func readClaudeAgents(dir, agentsDir string) ([]claudeAgent, []string, error) {
+	// Subagents are optional
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(agentsDir)))
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	var agents []claudeAgent
+	var warnings []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		rel := agentsDir + "/" + entry.Name()
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			return nil, nil, err
+		}
+		fm, err := ParseFrontmatter(data)
+		if errors.Is(err, ErrNoFrontmatter) {
+			return nil, nil, fmt.Errorf("%s missing YAML frontmatter", rel)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s frontmatter: %w", rel, err)
+		}
+		fields, err := fm.Entries()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s frontmatter: %w", rel, err)
+		}
+
+		agent := claudeAgent{File: rel, Name: strings.TrimSuffix(entry.Name(), ".md"), Prompt: strings.TrimSpace(fm.Body())}
+		for _, f := range fields {
+			s, _ := f.Value.(string)
+			switch {
+			case f.Key == "name" && agentNameRe.MatchString(s):
+				agent.Name = s
+			case f.Key == "name" && s != "":
+				warnings = append(warnings, fmt.Sprintf("%s: name %q is not lowercase letters, digits and hyphens; using %q", rel, s, agent.Name))
+			case f.Key == "description":
+				agent.Description = s
+			case !containsString(claudeAgentKeys, f.Key) && f.Value != nil:
+				agent.Lossy = append(agent.Lossy, f)
+			}
+		}
+		agents = append(agents, agent)
+	}
+	return agents, warnings, nil
+}
+
+// generateAgentCommands turns each subagent into a command in the agents
+// namespace and reports the fields that did not survive.
+func (c *ClaudeToGeminiConverter) generateAgentCommands() ([]string, error) {
+	var files []string
+	for _, agent := range c.agents {
+		description := agent.Description
+		if description == "" {
+			description = fmt.Sprintf("Activate %s agent", agent.Name)
+		}
+		cmd := &GeminiCommand{
+			Name:        geminiAgentsDir + "/" + agent.Name,
+			Description: description,
+			Comment:     fmt.Sprintf("Claude subagent: %s\nConverted from %s", agent.Name, agent.File),
+			Prompt:      agent.Prompt + agentQuerySuffix,
+		}
+		cmd.SetExtra(claudeSubagentKey, true)
+		file, err := c.addCommand(cmd, agent.File)
+		if err != nil {
+			return nil, err
+		}
+		if file == "" {
+			continue
+		}
+		files = append(files, file)
+
+		for _, f := range agent.Lossy {
+			reason, ok := lossyAgentReasons[f.Key]
+			if !ok {
+				reason = "Gemini commands have no equivalent"
+			}
+			c.lossy = append(c.lossy, domain.LossyField{File: agent.File, Field: f.Key, Value: fieldValue(f.Value), Reason: reason})
+		}
+	}
+	return files, nil
+}
+
+// fieldValue renders a frontmatter value for a loss report.
+func fieldValue(v any) string {
+	if list, ok := v.([]any); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprint(v)
+}
+
+// isClaudeSubagent reports whether cmd is a subagent converted by
+// generateAgentCommands.
+func isClaudeSubagent(cmd *GeminiCommand) bool {
+	name, ok := strings.CutPrefix(cmd.Name, geminiAgentsDir+"/")
+	marked, _ := cmd.Extra[claudeSubagentKey].(bool)
+	return ok && marked && !strings.Contains(name, "/")
+}
+
+// generateClaudeAgents turns the converted subagents back into subagent
+// files, updating the frontmatter of any that already exist.
+func (c *GeminiToClaudeConverter) generateClaudeAgents() ([]string, error) {
+	var files []string
+	for _, cmd := range c.agents {
+		rel := claudeAgentsDir + "/" + cmd.Name + ".md"
+		source := "commands/" + geminiAgentsDir + "/" + cmd.Name + ".toml"
+
+		fm := loadFrontmatter(c.plan.Abs(rel))
+		if err := fm.Set("name", cmd.Name); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if cmd.Description != "" {
+			if err := fm.Set("description", cmd.Description); err != nil {
+				return nil, fmt.Errorf("%s: %w", source, err)
+			}
+		}
+		var keys []string
+		for _, k := range cmd.UnknownKeys() {
+			if k != claudeSubagentKey {
+				keys = append(keys, k)
+			}
+		}
+		for _, k := range keys {
+			if err := fm.Set(k, cmd.Extra[k]); err != nil {
+				return nil, fmt.Errorf("%s: %w", source, err)
+			}
+		}
+		if len(keys) > 0 {
+			c.warnings = append(c.warnings, fmt.Sprintf("%s: kept unknown key(s) %s in %s frontmatter", source, strings.Join(keys, ", "), rel))
+		}
+
+		prompt := strings.TrimSpace(cmd.Prompt)
+		prompt = strings.TrimSpace(strings.TrimSuffix(prompt, strings.TrimSpace(agentQuerySuffix)))
+		if strings.Contains(prompt, "{{args}}") {
+			c.lossy = append(c.lossy, domain.LossyField{File: source, Field: "prompt", Value: "{{args}}",
+				Reason: "subagents receive the task from the main agent, not command arguments"})
+		}
+		fm.SetBody("\n" + prompt + "\n")
+
+		files = append(files, c.plan.Add(rel, fm.Bytes(), 0644))
+	}
+	return files, nil
+}
@@ -0,0 +1,138 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const agentSample = `---
+name: code-reviewer
+description: Reviews diffs for bugs and style issues
+tools: Read, Grep, Glob
+model: opus
+---
+
+You are a senior code reviewer.
+
+Check every change for:
+- correctness
+- "quoted" style issues
+`
+
+func writeAgentSkill(t *testing.T) string {
+	t.Helper()
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "agents"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "agents", "code-reviewer.md"), []byte(agentSample), 0644)
+	return src
+}
+
+func TestClaudeToGemini_Agents(t *testing.T) {
+	src := writeAgentSkill(t)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(src, "commands", "agents", "code-reviewer.toml"))
+	if err != nil {
+		t.Fatalf("agent command not written: %v", err)
+	}
+	want := `description = "Reviews diffs for bugs and style issues"
+
+# Claude subagent: code-reviewer
+# Converted from .claude/agents/code-reviewer.md
+prompt = """
+You are a senior code reviewer.
+
+Check every change for:
+- correctness
+- "quoted" style issues
+
+User Query: {{args}}
+"""
+
+claude-subagent = true
+`
+	if string(data) != want {
+		t.Errorf("Unexpected agent command:\ngot:\n%s\nwant:\n%s", data, want)
+	}
+
+	var lossy []string
+	for _, l := range result.Lossy {
+		lossy = append(lossy, l.String())
+	}
+	got := strings.Join(lossy, "\n")
+	for _, want := range []string{
+		".claude/agents/code-reviewer.md: tools = Read, Grep, Glob (Gemini commands cannot restrict tools",
+		".claude/agents/code-reviewer.md: model = opus (Gemini commands cannot choose a model",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected lossy field %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestClaudeToGemini_AgentNameIsFileName(t *testing.T) {
+	src := writeAgentSkill(t)
+	os.WriteFile(filepath.Join(src, ".claude", "agents", "escape.md"), []byte("---\nname: ../../../etc/evil\n---\n\nPrompt\n"), 0644)
+	out := filepath.Join(t.TempDir(), "out")
+
+	result, err := NewClaudeToGeminiConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if !fileExists(filepath.Join(out, "commands", "agents", "escape.toml")) {
+		t.Error("Expected the agent to be named after its file")
+	}
+	if !containsString(result.Warnings, `.claude/agents/escape.md: name "../../../etc/evil" is not lowercase letters, digits and hyphens; using "escape"`) {
+		t.Errorf("Expected a warning about the name, got %v", result.Warnings)
+	}
+}
+
+func TestGeminiToClaude_Agents(t *testing.T) {
+	mid := filepath.Join(t.TempDir(), "demo")
+	if _, err := NewClaudeToGeminiConverter(writeAgentSkill(t), mid).Convert(); err != nil {
+		t.Fatalf("Convert to Gemini failed: %v", err)
+	}
+
+	out := t.TempDir()
+	result, err := NewGeminiToClaudeConverter(mid, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert back failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, ".claude", "agents", "code-reviewer.md"))
+	if err != nil {
+		t.Fatalf("agent file not written: %v", err)
+	}
+	want := strings.Replace(agentSample, "tools: Read, Grep, Glob\nmodel: opus\n", "", 1)
+	if string(data) != want {
+		t.Errorf("Unexpected agent file:\ngot:\n%s\nwant:\n%s", data, want)
+	}
+	if fileExists(filepath.Join(out, ".claude", "commands", "agents.md")) || fileExists(filepath.Join(out, ".claude", "commands", "code-reviewer.md")) {
+		t.Error("Expected the agent not to become a slash command")
+	}
+	if len(result.Lossy) != 0 {
+		t.Errorf("Expected no lossy fields, got %v", result.Lossy)
+	}
+}
+
+func TestRoundtrip_Agents(t *testing.T) {
+	report, err := Roundtrip(writeAgentSkill(t), Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	for _, subject := range []string{"agent code-reviewer: tools", "agent code-reviewer: model"} {
+		if findFinding(report.Findings, "field-lost", subject) == nil {
+			t.Errorf("Expected field-lost %s, got %v", subject, report.Findings)
+		}
+	}
+	if f := findFinding(report.Findings, "agent-dropped", "code-reviewer"); f != nil {
+		t.Errorf("Expected the agent to survive, got %v", report.Findings)
+	}
+}
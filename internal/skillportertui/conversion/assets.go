@@ -0,0 +1,155 @@
+package conversion
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// A skill often ships scripts, reference docs or templates next to its
+// SKILL.md and links to them by relative path. When the output goes to
+// another directory those files are copied along, keeping their modes so
+// scripts stay executable, and the Markdown links of the converted context
+// file are rebased onto its new location. Links that leave the skill point
+// back at the original file.
+
+// assetDirs are copied whole, whether or not the context file links to them.
+var assetDirs = []string{"scripts", "references", "assets", "templates"}
+
+var (
+	markdownLinkRe = regexp.MustCompile(`(!?\[[^\]]*\]\()([^)\s]+)(\s+"[^"]*"\)|\))`)
+	// Bare paths such as `python scripts/fill.py` are relative to the skill
+	assetPathRe = regexp.MustCompile("(?:^|[\\s`'\"(])((?:\\./)?(?:scripts|references|assets|templates)/[\\w.-]+(?:/[\\w.-]+)*)")
+	urlSchemeRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+)
+
+// rebaseLinks rewrites the relative Markdown links of body, a document that
+// moves from srcFile under srcRoot to outFile under outRoot. It returns the
+// files under srcRoot the document references, slash-separated, and the
+// references that do not exist.
+func rebaseLinks(body, srcRoot, srcFile, outRoot, outFile string) (string, []string, []string) {
+	var refs, missing []string
+	srcDir := filepath.Join(srcRoot, filepath.FromSlash(path.Dir(srcFile)))
+	outDir := filepath.Join(outRoot, filepath.FromSlash(path.Dir(outFile)))
+
+	// resolve finds a reference on disk and records it
+	resolve := func(target, base string) (string, bool) {
+		abs := filepath.Join(base, filepath.FromSlash(target))
+		if _, err := os.Stat(abs); err != nil {
+			if !containsString(missing, target) {
+				missing = append(missing, target)
+			}
+			return abs, false
+		}
+		if rel, err := filepath.Rel(srcRoot, abs); err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
+			if rel = filepath.ToSlash(rel); !containsString(refs, rel) {
+				refs = append(refs, rel)
+			}
+		}
+		return abs, true
+	}
+
+	body = markdownLinkRe.ReplaceAllStringFunc(body, func(m string) string {
+		sub := markdownLinkRe.FindStringSubmatch(m)
+		target, suffix := sub[2], ""
+		if i := strings.IndexAny(target, "#?"); i >= 0 {
+			target, suffix = target[:i], target[i:]
+		}
+		if target == "" || strings.HasPrefix(target, "/") || urlSchemeRe.MatchString(target) {
+			return m
+		}
+		abs, ok := resolve(target, srcDir)
+		if !ok {
+			return m
+		}
+
+		// Files inside the skill are copied to the same place in the output
+		dest := abs
+		if rel, err := filepath.Rel(srcRoot, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			dest = filepath.Join(outRoot, rel)
+		}
+		rebased, err := relativePath(outDir, dest)
+		if err != nil || rebased == path.Clean(target) {
+			return m
+		}
+		if strings.HasSuffix(target, "/") {
+			rebased += "/"
+		}
+		return sub[1] + rebased + suffix + sub[3]
+	})
+
+	for _, m := range assetPathRe.FindAllStringSubmatch(body, -1) {
+		resolve(strings.TrimRight(m[1], "."), srcRoot)
+	}
+	return body, refs, missing
+}
+
+// relativePath is filepath.Rel between absolute forms of both paths,
+// slash-separated.
+func relativePath(from, to string) (string, error) {
+	from, err := filepath.Abs(from)
+	if err != nil {
+		return "", err
+	}
+	to, err = filepath.Abs(to)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(from, to)
+	return filepath.ToSlash(rel), err
+}
+
+// copyAssets adds the asset directories of the skill at srcRoot and the
+// files it references to the plan, unless the plan writes into srcRoot
+// itself. Files the conversion generates are not replaced. It returns the
+// destination paths of the copies.
+func copyAssets(p *Plan, srcRoot string, refs []string) ([]string, error) {
+	absSrc, _ := filepath.Abs(srcRoot)
+	absOut, _ := filepath.Abs(p.OutputDir)
+	if absSrc == absOut {
+		return nil, nil
+	}
+
+	before := len(p.files)
+	for _, rel := range append(append([]string{}, assetDirs...), refs...) {
+		src := filepath.Join(srcRoot, filepath.FromSlash(rel))
+		info, err := os.Stat(src)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			err = p.addTree(src, rel, true)
+		} else {
+			err = p.addFile(src, rel, info.Mode(), true)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, f := range p.files[before:] {
+		files = append(files, p.Abs(f.Path))
+	}
+	return files, nil
+}
+
+// addFile schedules a copy of the regular file src at rel, with its mode.
+// With keep set an entry already planned for rel wins.
+func (p *Plan) addFile(src, rel string, mode fs.FileMode, keep bool) error {
+	if !mode.IsRegular() {
+		return nil
+	}
+	if _, ok := p.index[path.Clean(filepath.ToSlash(rel))]; ok && keep {
+		return nil
+	}
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	p.Add(rel, content, mode.Perm())
+	return nil
+}
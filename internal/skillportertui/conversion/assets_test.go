@@ -0,0 +1,107 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClaudeToGemini_CopiesAssets(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "skills", "pdf")
+	os.MkdirAll(filepath.Join(src, "scripts"), 0755)
+	os.MkdirAll(filepath.Join(src, "docs"), 0755)
+	os.WriteFile(filepath.Join(root, "STYLE.md"), []byte("# Style\n"), 0644)
+	os.WriteFile(filepath.Join(src, "scripts", "fill.py"), []byte("#!/usr/bin/env python3\n"), 0755)
+	os.WriteFile(filepath.Join(src, "docs", "forms.md"), []byte("# Forms\n"), 0644)
+	os.WriteFile(filepath.Join(src, "unrelated.txt"), []byte("not referenced\n"), 0644)
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte(`---
+name: pdf
+description: Fill PDF forms
+---
+
+See [forms](./docs/forms.md#fields), the [style guide](../../STYLE.md "Style")
+and [the spec](https://example.com/spec.md).
+
+Run `+"`python scripts/fill.py`"+`, then `+"`scripts/check.py`"+`. Read [notes](notes.md).
+`), 0644)
+
+	out := filepath.Join(root, "pdf-gemini")
+	result, err := NewClaudeToGeminiConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(out, "scripts", "fill.py"))
+	if err != nil {
+		t.Fatalf("script not copied: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected the script to stay executable, got %v", info.Mode().Perm())
+	}
+	if !fileExists(filepath.Join(out, "docs", "forms.md")) {
+		t.Error("Expected the linked doc to be copied")
+	}
+	if fileExists(filepath.Join(out, "unrelated.txt")) {
+		t.Error("Expected files nothing references to stay behind")
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "GEMINI.md"))
+	for _, want := range []string{
+		"[forms](./docs/forms.md#fields)",
+		// Links out of the skill point back at the original file
+		`[style guide](../STYLE.md "Style")`,
+		"[the spec](https://example.com/spec.md)",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected GEMINI.md to contain %q, got:\n%s", want, data)
+		}
+	}
+
+	want := "SKILL.md: notes.md, SKILL.md: scripts/check.py"
+	if got := strings.Join(result.MissingFiles, ", "); got != want {
+		t.Errorf("Expected missing files %q, got %q", want, got)
+	}
+}
+
+func TestGeminiToClaude_RebasesContextLinks(t *testing.T) {
+	src := t.TempDir()
+	os.MkdirAll(filepath.Join(src, "context"), 0755)
+	os.MkdirAll(filepath.Join(src, "references"), 0755)
+	os.WriteFile(filepath.Join(src, "references", "api.md"), []byte("# API\n"), 0644)
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"),
+		[]byte(`{"name": "demo", "version": "1.0.0", "description": "Demo", "contextFileName": "context/DEMO.md"}`), 0644)
+	os.WriteFile(filepath.Join(src, "context", "DEMO.md"), []byte("# Demo\n\nSee [the API](../references/api.md).\n"), 0644)
+
+	out := t.TempDir()
+	result, err := NewGeminiToClaudeConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "SKILL.md"))
+	if !strings.Contains(string(data), "[the API](references/api.md)") {
+		t.Errorf("Expected the link to be rebased onto SKILL.md, got:\n%s", data)
+	}
+	if !fileExists(filepath.Join(out, "references", "api.md")) {
+		t.Error("Expected references/ to be copied")
+	}
+	if len(result.MissingFiles) != 0 {
+		t.Errorf("Expected no missing files, got %v", result.MissingFiles)
+	}
+}
+
+func TestCopyAssets_InPlace(t *testing.T) {
+	src := t.TempDir()
+	os.MkdirAll(filepath.Join(src, "scripts"), 0755)
+	os.WriteFile(filepath.Join(src, "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0755)
+
+	files, err := copyAssets(NewPlan(src), src, []string{"scripts/run.sh"})
+	if err != nil {
+		t.Fatalf("copyAssets failed: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected nothing to be copied in place, got %v", files)
+	}
+}
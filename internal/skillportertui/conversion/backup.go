@@ -0,0 +1,278 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// snapshotManifest is the name of the file describing a snapshot.
+const snapshotManifest = "snapshot.json"
+
+// ErrNoSnapshot is returned when a skill has no snapshot to restore.
+var ErrNoSnapshot = errors.New("no backup found for skill")
+
+// BackupStore keeps snapshots of the files in-place conversions overwrite,
+// so a skill can be restored to its state before the conversion. Each run of
+// the TUI gets its own session directory under Root.
+type BackupStore struct {
+	Root    string
+	Session string
+
+	mu  sync.Mutex // Conversions run concurrently
+	seq int
+}
+
+// SnapshotFile is one file a conversion touched.
+type SnapshotFile struct {
+	Path    string      `json:"path"` // Relative to the skill, slash-separated
+	Existed bool        `json:"existed"`
+	Mode    fs.FileMode `json:"mode,omitempty"`
+}
+
+// Snapshot is the pre-conversion state of the files one conversion touched.
+type Snapshot struct {
+	SkillPath string         `json:"skillPath"`
+	Created   time.Time      `json:"created"`
+	Files     []SnapshotFile `json:"files"`
+	// CreatedDirs are directories the conversion created, deepest first.
+	CreatedDirs []string `json:"createdDirs,omitempty"`
+
+	dir string
+}
+
+// DefaultBackupRoot returns the directory holding all backup sessions.
+func DefaultBackupRoot() string {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+	return filepath.Join(base, "skill-porter", "backups")
+}
+
+// NewBackupStore starts a new backup session under root.
+func NewBackupStore(root string) *BackupStore {
+	session := fmt.Sprintf("%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+	return &BackupStore{Root: root, Session: session}
+}
+
+// Snapshot saves the current state of every file plan would write under
+// skillPath. It must be called before the plan is applied.
+func (s *BackupStore) Snapshot(skillPath string, plan *Plan) (*Snapshot, error) {
+	skillPath, err := filepath.Abs(skillPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.seq++
+	seq := s.seq
+	s.mu.Unlock()
+
+	dir := filepath.Join(s.Root, s.Session, fmt.Sprintf("%04d-%s", seq, filepath.Base(skillPath)))
+	snap := &Snapshot{SkillPath: skillPath, Created: time.Now(), dir: dir}
+	missingDirs := map[string]bool{}
+
+	for _, f := range plan.Files() {
+		dest := plan.Abs(f.Path)
+		entry := SnapshotFile{Path: f.Path}
+
+		if info, err := os.Stat(dest); err == nil {
+			content, err := os.ReadFile(dest)
+			if err != nil {
+				return nil, err
+			}
+			backup := filepath.Join(dir, "files", filepath.FromSlash(f.Path))
+			if err := os.MkdirAll(filepath.Dir(backup), 0755); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(backup, content, 0600); err != nil {
+				return nil, err
+			}
+			entry.Existed = true
+			entry.Mode = info.Mode().Perm()
+		}
+		snap.Files = append(snap.Files, entry)
+
+		for d := filepath.Dir(dest); d != plan.OutputDir && len(d) > len(plan.OutputDir); d = filepath.Dir(d) {
+			if _, err := os.Stat(d); err == nil {
+				break
+			}
+			rel, _ := filepath.Rel(plan.OutputDir, d)
+			missingDirs[filepath.ToSlash(rel)] = true
+		}
+	}
+
+	for d := range missingDirs {
+		snap.CreatedDirs = append(snap.CreatedDirs, d)
+	}
+	// Deepest first, so children are removed before their parents
+	sort.Slice(snap.CreatedDirs, func(i, j int) bool {
+		return len(snap.CreatedDirs[i]) > len(snap.CreatedDirs[j])
+	})
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotManifest), data, 0644); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Latest returns the most recent snapshot of skillPath in this session.
+func (s *BackupStore) Latest(skillPath string) (*Snapshot, error) {
+	return latestSnapshot(filepath.Join(s.Root, s.Session), skillPath)
+}
+
+// FindLatestSnapshot returns the most recent snapshot of skillPath in any
+// session under root.
+func FindLatestSnapshot(root, skillPath string) (*Snapshot, error) {
+	sessions, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSnapshot
+		}
+		return nil, err
+	}
+
+	var latest *Snapshot
+	for _, session := range sessions {
+		if !session.IsDir() {
+			continue
+		}
+		snap, err := latestSnapshot(filepath.Join(root, session.Name()), skillPath)
+		if err != nil {
+			continue
+		}
+		if latest == nil || snap.Created.After(latest.Created) {
+			latest = snap
+		}
+	}
+	if latest == nil {
+		return nil, ErrNoSnapshot
+	}
+	return latest, nil
+}
+
+func latestSnapshot(sessionDir, skillPath string) (*Snapshot, error) {
+	skillPath, err := filepath.Abs(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(sessionDir)
+	if err != nil {
+		return nil, ErrNoSnapshot
+	}
+
+	// Snapshot directories are named <seq>-<skill>; walk newest first. The
+	// sequence is compared as a number, since past 9999 it outgrows its padding
+	sort.SliceStable(entries, func(i, j int) bool {
+		return snapshotSeq(entries[i].Name()) > snapshotSeq(entries[j].Name())
+	})
+	for _, entry := range entries {
+		dir := filepath.Join(sessionDir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(dir, snapshotManifest))
+		if err != nil {
+			continue
+		}
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			continue
+		}
+		if snap.SkillPath == skillPath {
+			snap.dir = dir
+			return &snap, nil
+		}
+	}
+	return nil, ErrNoSnapshot
+}
+
+// snapshotSeq returns the sequence number of a snapshot directory, or -1.
+func snapshotSeq(name string) int {
+	prefix, _, _ := strings.Cut(name, "-")
+	seq, err := strconv.Atoi(prefix)
+	if err != nil {
+		return -1
+	}
+	return seq
+}
+
+// Restore puts every file back as it was when the snapshot was taken,
+// deleting files and directories the conversion created, and then discards
+// the snapshot so that an earlier one becomes the latest.
+func (snap *Snapshot) Restore() error {
+	for _, f := range snap.Files {
+		dest := filepath.Join(snap.SkillPath, filepath.FromSlash(f.Path))
+		if !f.Existed {
+			if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(snap.dir, "files", filepath.FromSlash(f.Path)))
+		if err != nil {
+			return fmt.Errorf("read backup of %s: %w", f.Path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, content, f.Mode); err != nil {
+			return err
+		}
+		if err := os.Chmod(dest, f.Mode); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range snap.CreatedDirs {
+		// Only empty directories are removed; anything added since is kept
+		os.Remove(filepath.Join(snap.SkillPath, filepath.FromSlash(d)))
+	}
+
+	return snap.Discard()
+}
+
+// Discard deletes the snapshot without restoring it, e.g. when the
+// conversion it guarded failed and was rolled back.
+func (snap *Snapshot) Discard() error {
+	if snap == nil {
+		return nil
+	}
+	return os.RemoveAll(snap.dir)
+}
+
+// ApplyPlan applies plan. When skillPath is converted in place (empty outDir)
+// and backups is set, the files it touches are snapshotted first; the
+// snapshot is dropped again if the plan cannot be applied.
+func ApplyPlan(ctx context.Context, plan *Plan, skillPath, outDir string, backups *BackupStore) error {
+	if plan == nil {
+		return nil
+	}
+	var snap *Snapshot
+	if outDir == "" && backups != nil {
+		var err error
+		if snap, err = backups.Snapshot(skillPath, plan); err != nil {
+			return fmt.Errorf("backup before conversion: %w", err)
+		}
+	}
+	if err := plan.ApplyContext(ctx); err != nil {
+		snap.Discard()
+		return err
+	}
+	return nil
+}
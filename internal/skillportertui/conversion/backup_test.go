@@ -0,0 +1,139 @@
+package conversion
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestBackup_RestoresInPlaceConversion(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.WriteFile(filepath.Join(src, "GEMINI.md"), []byte("hand-written context\n"), 0600)
+	before := snapshot(t, src)
+
+	backups := NewBackupStore(t.TempDir())
+	skill := domain.SkillDir{Name: "demo", Path: src}
+	if _, err := (NativeConverter{Backups: backups}).Convert(context.Background(), skill, domain.TargetGemini, ""); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(src, "GEMINI.md")); string(data) == "hand-written context\n" {
+		t.Fatal("Expected the conversion to overwrite GEMINI.md")
+	}
+
+	snap, err := backups.Latest(src)
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if err := snap.Restore(); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	after := snapshot(t, src)
+	if len(after) != len(before) {
+		t.Errorf("Expected exactly the original files, got %v", after)
+	}
+	for path, content := range before {
+		if after[path] != content {
+			t.Errorf("%s: expected %q, got %q", path, content, after[path])
+		}
+	}
+	if info, _ := os.Stat(filepath.Join(src, "GEMINI.md")); info.Mode().Perm() != 0600 {
+		t.Errorf("Expected GEMINI.md mode 0600 to be restored, got %o", info.Mode().Perm())
+	}
+	for _, dir := range []string{"docs", "shared"} {
+		if _, err := os.Stat(filepath.Join(src, dir)); !os.IsNotExist(err) {
+			t.Errorf("Expected created directory %s/ to be removed", dir)
+		}
+	}
+
+	// The snapshot is consumed by the restore
+	if _, err := backups.Latest(src); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot after restore, got %v", err)
+	}
+}
+
+func TestBackup_OnlyInPlace(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n"), 0644)
+
+	backups := NewBackupStore(t.TempDir())
+	skill := domain.SkillDir{Name: "demo", Path: src}
+	out := filepath.Join(t.TempDir(), "demo")
+	if _, err := (NativeConverter{Backups: backups}).Convert(context.Background(), skill, domain.TargetGemini, out); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if _, err := backups.Latest(src); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected no snapshot for an out-of-place conversion, got %v", err)
+	}
+}
+
+func TestBackup_LatestPastPadding(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "a.txt"), []byte("v1"), 0644)
+
+	backups := NewBackupStore(t.TempDir())
+	backups.seq = 9998
+	for _, content := range []string{"v2", "v3"} {
+		plan := NewPlan(src)
+		plan.Add("a.txt", []byte(content), 0644)
+		if err := ApplyPlan(context.Background(), plan, src, "", backups); err != nil {
+			t.Fatalf("ApplyPlan failed: %v", err)
+		}
+	}
+
+	// Snapshots 9999 and 10000: the latest holds v2
+	snap, err := backups.Latest(src)
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if err := snap.Restore(); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(src, "a.txt")); string(data) != "v2" {
+		t.Errorf("Expected the newest snapshot to restore v2, got %q", data)
+	}
+}
+
+func TestFindLatestSnapshot_AcrossSessions(t *testing.T) {
+	root := t.TempDir()
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "a.txt"), []byte("v1"), 0644)
+
+	first := NewBackupStore(root)
+	first.Session = "first"
+	plan := NewPlan(src)
+	plan.Add("a.txt", []byte("v2"), 0644)
+	if err := ApplyPlan(context.Background(), plan, src, "", first); err != nil {
+		t.Fatalf("ApplyPlan failed: %v", err)
+	}
+
+	second := NewBackupStore(root)
+	second.Session = "second"
+	plan = NewPlan(src)
+	plan.Add("a.txt", []byte("v3"), 0644)
+	if err := ApplyPlan(context.Background(), plan, src, "", second); err != nil {
+		t.Fatalf("ApplyPlan failed: %v", err)
+	}
+
+	// Each undo steps back one conversion
+	for _, want := range []string{"v2", "v1"} {
+		snap, err := FindLatestSnapshot(root, src)
+		if err != nil {
+			t.Fatalf("FindLatestSnapshot failed: %v", err)
+		}
+		if err := snap.Restore(); err != nil {
+			t.Fatalf("Restore failed: %v", err)
+		}
+		if data, _ := os.ReadFile(filepath.Join(src, "a.txt")); string(data) != want {
+			t.Errorf("Expected %q after undo, got %q", want, data)
+		}
+	}
+	if _, err := FindLatestSnapshot(root, src); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Expected ErrNoSnapshot, got %v", err)
+	}
+}
@@ -0,0 +1,603 @@
+package conversion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/mcp"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	envVarRefRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// skillFrontmatter is the subset of SKILL.md frontmatter used by the converter.
+type skillFrontmatter struct {
+	Name         string     `yaml:"name"`
+	Description  string     `yaml:"description"`
+	AllowedTools toolList   `yaml:"allowed-tools"`
+	Subagents    []subagent `yaml:"subagents"`
+}
+
+type subagent struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// toolList accepts allowed-tools either as a YAML sequence or as a
+// comma-separated string.
+type toolList []string
+
+func (t *toolList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		var tools []string
+		for _, tool := range strings.Split(s, ",") {
+			tools = append(tools, strings.TrimSpace(tool))
+		}
+		*t = tools
+		return nil
+	}
+	var tools []string
+	if err := node.Decode(&tools); err != nil {
+		return err
+	}
+	*t = tools
+	return nil
+}
+
+type claudeCommand struct {
+	Name    string
+	Content string
+}
+
+// Setting is one entry of the settings array in gemini-extension.json.
+type Setting struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Secret      bool   `json:"secret,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Default     any    `json:"default,omitempty"`
+}
+
+// ClaudeToGeminiConverter converts a Claude Code skill into a Gemini CLI
+// extension. It is a port of ClaudeToGeminiConverter in
+// src/converters/claude-to-gemini.js and produces the same files.
+type ClaudeToGeminiConverter struct {
+	SourcePath string
+	OutputPath string
+	Options    Options
+
+	frontmatter skillFrontmatter
+	content     string
+	commandsDir string // Where the commands were read from, relative to the skill
+	commands    []claudeCommand
+	agents      []claudeAgent
+	marketplace *jsonobj.Object
+	mcpConfig   *jsonobj.Object // mcpServers of .mcp.json, if the skill has one
+	warnings    []string
+	rewrites    []domain.MCPRewrite
+	settings    []domain.InferredSetting
+	lossy       []domain.LossyField
+	previews    []domain.CommandPreview
+	sources     map[string]string // Source of each generated command file
+	assets      []string          // Files under the skill that SKILL.md references
+	missing     []string
+	plan        *Plan
+
+	// Set when converting a plugin, see PluginToGeminiConverter
+	pluginManifest *jsonobj.Object // .claude-plugin/plugin.json
+	contextRebased bool            // content links already start from GEMINI.md
+}
+
+// NewClaudeToGeminiConverter creates a converter. An empty outputPath converts in place.
+func NewClaudeToGeminiConverter(sourcePath, outputPath string) *ClaudeToGeminiConverter {
+	if outputPath == "" {
+		outputPath = sourcePath
+	}
+	return &ClaudeToGeminiConverter{SourcePath: sourcePath, OutputPath: outputPath, commandsDir: claudeCommandsDir}
+}
+
+// Convert performs the conversion and returns the generated files.
+func (c *ClaudeToGeminiConverter) Convert() (*Result, error) {
+	result, err := c.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Plan.Apply(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Plan builds the conversion without writing anything to disk.
+func (c *ClaudeToGeminiConverter) Plan() (*Result, error) {
+	return c.planInto(NewPlan(c.OutputPath))
+}
+
+func (c *ClaudeToGeminiConverter) planInto(plan *Plan) (*Result, error) {
+	c.plan = plan
+	result := &Result{Source: domain.PlatformClaude, Plan: plan}
+
+	if err := c.extractClaudeMetadata(); err != nil {
+		return nil, err
+	}
+	return c.generate(result)
+}
+
+// generate adds the extension to c.plan once the skill has been read.
+func (c *ClaudeToGeminiConverter) generate(result *Result) (*Result, error) {
+	manifestPath, err := c.generateGeminiManifest()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, manifestPath)
+
+	contextPath, err := c.generateGeminiContext()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, contextPath)
+
+	// Subagents go first: they own the agents namespace, so a slash command
+	// in .claude/commands/agents/ that collides with one is the one skipped
+	agentFiles, err := c.generateAgentCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	commandFiles, err := c.generateCommands()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, commandFiles...)
+	result.Files = append(result.Files, agentFiles...)
+
+	assetFiles, err := copyAssets(c.plan, c.SourcePath, c.assets)
+	if err != nil {
+		return nil, fmt.Errorf("copy assets: %w", err)
+	}
+	result.Files = append(result.Files, assetFiles...)
+
+	ensureSharedStructure(c.plan)
+	c.injectDocs()
+
+	result.Warnings = c.warnings
+	result.MCPRewrites = c.rewrites
+	result.Settings = c.settings
+	result.Lossy = c.lossy
+	result.Commands = c.previews
+	result.MissingFiles = c.missing
+	return result, nil
+}
+
+func (c *ClaudeToGeminiConverter) extractClaudeMetadata() error {
+	data, err := os.ReadFile(filepath.Join(c.SourcePath, "SKILL.md"))
+	if err != nil {
+		return err
+	}
+	fm, err := ParseFrontmatter(data)
+	if errors.Is(err, ErrNoFrontmatter) {
+		return fmt.Errorf("SKILL.md missing YAML frontmatter")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid SKILL.md frontmatter: %w", err)
+	}
+	if err := fm.Decode(&c.frontmatter); err != nil {
+		return fmt.Errorf("invalid SKILL.md frontmatter: %w", err)
+	}
+	c.content = fm.Body()
+
+	// Slash commands are optional
+	if c.commands, err = readClaudeCommands(c.SourcePath, c.commandsDir); err != nil {
+		return err
+	}
+	var agentWarnings []string
+	if c.agents, agentWarnings, err = readClaudeAgents(c.SourcePath, claudeAgentsDir); err != nil {
+		return err
+	}
+	c.warnings = append(c.warnings, agentWarnings...)
+
+	// marketplace.json and .mcp.json are optional
+	marketplace, err := jsonobj.Read(filepath.Join(c.SourcePath, ".claude-plugin", "marketplace.json"))
+	if err == nil {
+		c.marketplace = marketplace
+	}
+	if mcpFile, err := jsonobj.Read(filepath.Join(c.SourcePath, ".mcp.json")); err == nil {
+		c.mcpConfig = mcpFile.Object("mcpServers")
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("invalid .mcp.json: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ClaudeToGeminiConverter) generateGeminiManifest() (string, error) {
+	plugin := firstPlugin(c.marketplace)
+	if c.pluginManifest != nil {
+		plugin = c.pluginManifest
+	}
+
+	var version any = "1.0.0"
+	if v, ok := c.marketplace.Object("metadata").Get("version"); ok && isTruthy(v) {
+		version = v
+	} else if v, ok := c.pluginManifest.Get("version"); ok && isTruthy(v) {
+		version = v
+	}
+
+	description := c.frontmatter.Description
+	if description == "" {
+		description = plugin.String("description")
+	}
+
+	manifest := jsonobj.New()
+	manifest.Set("name", c.frontmatter.Name)
+	manifest.Set("version", version)
+	manifest.Set("description", description)
+	manifest.Set("contextFileName", "GEMINI.md")
+
+	var mcpServers *jsonobj.Object
+	if servers := c.claudeMCPServers(plugin); servers != nil {
+		mcpServers, c.rewrites = mcp.ToGemini(servers)
+		manifest.Set("mcpServers", mcpServers)
+		for _, r := range c.rewrites {
+			if r.Lossy {
+				c.warnings = append(c.warnings, "mcpServers."+r.String())
+			}
+		}
+	}
+
+	if c.frontmatter.AllowedTools != nil {
+		exclude, claudeOnly := c.convertAllowedToolsToExclude(c.frontmatter.AllowedTools, mcpServers)
+		manifest.Set("excludeTools", exclude)
+		if len(claudeOnly) > 0 {
+			manifest.Set(claudeExcludeToolsKey, claudeOnly)
+		}
+	}
+
+	if mcpServers != nil {
+		if settings := c.inferSettings(mcpServers); len(settings) > 0 {
+			manifest.Set("settings", settings)
+		}
+	}
+
+	data, err := jsonobj.MarshalFile(manifest)
+	if err != nil {
+		return "", err
+	}
+	return c.plan.Add("gemini-extension.json", data, 0644), nil
+}
+
+// claudeMCPServers returns the MCP servers of the marketplace plugin and of
+// .mcp.json. A server defined in both is taken from the marketplace.
+func (c *ClaudeToGeminiConverter) claudeMCPServers(plugin *jsonobj.Object) *jsonobj.Object {
+	servers := plugin.Object("mcpServers")
+	if c.mcpConfig == nil {
+		return servers
+	}
+	if servers == nil {
+		return c.mcpConfig
+	}
+	manifest := "marketplace.json"
+	if c.pluginManifest != nil {
+		manifest = "plugin.json"
+	}
+	merged := servers.Clone()
+	for _, name := range c.mcpConfig.Keys() {
+		if _, ok := merged.Get(name); ok {
+			c.warnings = append(c.warnings, fmt.Sprintf("MCP server %q is defined in both %s and .mcp.json; using %s", name, manifest, manifest))
+			continue
+		}
+		v, _ := c.mcpConfig.Get(name)
+		merged.Set(name, v)
+	}
+	return merged
+}
+
+// convertAllowedToolsToExclude turns Claude's allowed-tools whitelist into
+// Gemini's excludeTools blacklist, using Gemini's names for the tools, and
+// returns the excluded Claude tools Gemini has no equivalent for separately
+// so that converting back restores them. Allowed MCP tools become
+// includeTools on their server.
+func (c *ClaudeToGeminiConverter) convertAllowedToolsToExclude(allowed []string, mcpServers *jsonobj.Object) ([]string, []string) {
+	catalog := c.Options.catalog()
+	allowedTools := map[string]bool{}
+	mcpTools := map[string][]string{} // Server -> tools; nil when the whole server is allowed
+	for _, name := range allowed {
+		if server, tool, ok := tools.SplitMCP(name); ok {
+			if list, seen := mcpTools[server]; tool == "" {
+				mcpTools[server] = nil
+			} else if !seen || list != nil {
+				mcpTools[server] = append(list, tool)
+			}
+			continue
+		}
+		base, scope := splitToolScope(name)
+		tool, ok := catalog.Lookup(domain.PlatformClaude, base)
+		if !ok {
+			c.warnings = append(c.warnings, fmt.Sprintf("allowed-tools: unknown tool %q is not in the tool catalog and was ignored", name))
+			continue
+		}
+		allowedTools[toolKey(tool)] = true
+		// excludeTools can only take a tool away, not narrow it
+		if scope != "" {
+			c.lossy = append(c.lossy, domain.LossyField{File: "SKILL.md", Field: "allowed-tools", Value: name,
+				Reason: fmt.Sprintf("excludeTools cannot limit %s to %s, so the whole tool is allowed", tool.Name(domain.PlatformGemini), scope)})
+		}
+	}
+	c.restrictMCPServers(mcpTools, mcpServers)
+
+	excluded, claudeOnly := []string{}, []string{}
+	for _, tool := range catalog.Tools {
+		switch {
+		case allowedTools[toolKey(tool)]:
+		case tool.Gemini != "":
+			excluded = append(excluded, tool.Gemini)
+		default:
+			claudeOnly = append(claudeOnly, tool.Claude)
+		}
+	}
+
+	if len(excluded) <= len(allowedTools) {
+		// If more tools are allowed than excluded the restriction cannot be
+		// expressed exactly, so leave it open and warn.
+		c.warnings = append(c.warnings, "Tool restrictions may not translate exactly - review excludeTools in gemini-extension.json")
+		return []string{}, nil
+	}
+	if len(claudeOnly) > 0 {
+		c.lossy = append(c.lossy, domain.LossyField{File: "SKILL.md", Field: "allowed-tools", Value: "excludes " + strings.Join(claudeOnly, ", "),
+			Reason: "Gemini has no equivalent of these tools, so excludeTools cannot name them; they are kept in " + claudeExcludeToolsKey + " for converting back"})
+	}
+	return excluded, claudeOnly
+}
+
+// splitToolScope splits a scoped tool such as Bash(git diff:*) into the tool
+// and its specifier.
+func splitToolScope(name string) (tool, scope string) {
+	if i := strings.IndexByte(name, '('); i > 0 && strings.HasSuffix(name, ")") {
+		return strings.TrimSpace(name[:i]), name[i+1 : len(name)-1]
+	}
+	return name, ""
+}
+
+// restrictMCPServers sets includeTools on every server that allowed-tools
+// names individual tools of.
+func (c *ClaudeToGeminiConverter) restrictMCPServers(mcpTools map[string][]string, mcpServers *jsonobj.Object) {
+	for _, server := range sortedKeys(mcpTools) {
+		config := mcpServers.Object(server)
+		if config == nil {
+			c.warnings = append(c.warnings, fmt.Sprintf("allowed-tools: MCP server %q is not configured, so its tools were ignored", server))
+			continue
+		}
+		if list := mcpTools[server]; list != nil {
+			config.Set("includeTools", list)
+		}
+	}
+}
+
+// inferSettings builds the settings schema from the ${VAR} references in
+// MCP server environments, described by the settings rules.
+func (c *ClaudeToGeminiConverter) inferSettings(mcpServers *jsonobj.Object) []Setting {
+	settings := []Setting{}
+	index := make(map[string]int)
+	rules := c.Options.rules()
+
+	for _, serverName := range mcpServers.Keys() {
+		env := mcpServers.Object(serverName).Object("env")
+		for _, key := range env.Keys() {
+			v, _ := env.Get(key)
+			value, ok := v.(string)
+			if !ok {
+				continue
+			}
+			for _, m := range envVarRefRe.FindAllStringSubmatch(value, -1) {
+				varName := m[1]
+				if i, ok := index[varName]; ok {
+					inferred := &c.settings[i]
+					if !containsString(inferred.Servers, serverName) {
+						inferred.Servers = append(inferred.Servers, serverName)
+					}
+					continue
+				}
+
+				inferred := rules.Infer(varName)
+				inferred.Servers = []string{serverName}
+				index[varName] = len(c.settings)
+				c.settings = append(c.settings, inferred)
+
+				setting := Setting{
+					Name:        inferred.Name,
+					Description: inferred.Description,
+					Secret:      inferred.Secret,
+					Required:    inferred.Required,
+				}
+				if inferred.Default != "" {
+					setting.Default = inferred.Default
+				}
+				settings = append(settings, setting)
+			}
+		}
+	}
+
+	return settings
+}
+
+func (c *ClaudeToGeminiConverter) generateGeminiContext() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s - Gemini CLI Extension\n\n", c.frontmatter.Name)
+	fmt.Fprintf(&b, "%s\n\n", c.frontmatter.Description)
+	b.WriteString("## Quick Start\n\nAfter installation, you can use this extension by asking questions or giving commands naturally.\n\n")
+	// SKILL.md links are rebased onto GEMINI.md
+	content := c.content
+	if !c.contextRebased {
+		var refs, missing []string
+		content, refs, missing = rebaseLinks(c.content, c.SourcePath, "SKILL.md", c.OutputPath, "GEMINI.md")
+		c.assets = append(c.assets, refs...)
+		for _, m := range missing {
+			c.missing = append(c.missing, "SKILL.md: "+m)
+		}
+	}
+	b.WriteString(content)
+	b.WriteString("\n\n---\n\n")
+	fmt.Fprintf(&b, "*This extension was converted from a Claude Code skill using [skill-porter](%s)*\n", converterRepoURL)
+
+	return c.plan.Add("GEMINI.md", []byte(b.String()), 0644), nil
+}
+
+// generateCommands turns subagents and Claude slash commands into Gemini
+// custom commands under commands/*.toml.
+func (c *ClaudeToGeminiConverter) generateCommands() ([]string, error) {
+	var files []string
+	if len(c.frontmatter.Subagents) == 0 && len(c.commands) == 0 {
+		return files, nil
+	}
+
+	for _, agent := range c.frontmatter.Subagents {
+		cmd := &GeminiCommand{
+			Name:        agent.Name,
+			Description: fmt.Sprintf("Activate %s agent", agent.Name),
+			Comment:     fmt.Sprintf("Agent Persona: %s\nAuto-generated from Claude Subagent", agent.Name),
+			Prompt:      fmt.Sprintf("You are acting as the '%s' agent.\n%s\n\nUser Query: {{args}}", agent.Name, agent.Description),
+		}
+		file, err := c.addCommand(cmd, "SKILL.md subagent "+agent.Name)
+		if err != nil {
+			return nil, err
+		}
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	for _, claudeCmd := range c.commands {
+		cmd := &GeminiCommand{Name: claudeCmd.Name, Description: "Custom command: " + claudeCmd.Name}
+		source := c.commandsDir + "/" + claudeCmd.Name + ".md"
+		prompt := claudeCmd.Content
+		var argumentHint string
+
+		// A command without frontmatter is all prompt; one whose YAML is
+		// invalid loses its frontmatter, which is reported
+		fm, err := ParseFrontmatter([]byte(claudeCmd.Content))
+		var entries []yamlEntry
+		if err == nil {
+			entries, err = fm.Entries()
+		}
+		switch {
+		case err == nil:
+			prompt = fm.Body()
+			argumentHint = c.mapCommandFrontmatter(cmd, source, entries)
+		case !errors.Is(err, ErrNoFrontmatter):
+			if raw, splitErr := splitFrontmatter([]byte(claudeCmd.Content)); splitErr == nil {
+				prompt = raw.Body()
+				c.lossy = append(c.lossy, domain.LossyField{File: source, Field: "frontmatter", Value: strings.Join(raw.lines, "; "),
+					Reason: "the YAML is invalid, so none of it was converted"})
+			}
+			c.warnings = append(c.warnings, fmt.Sprintf("commands/%s.toml: invalid frontmatter in %s was dropped: %v", cmd.Name, source, err))
+		}
+
+		// Claude: $ARGUMENTS -> Gemini: {{args}}, with $1, $2, ... named;
+		// !`cmd` -> !{cmd}; @path -> @{path}
+		prompt, rewrites := translateClaudePrompt(prompt, argumentHint)
+		cmd.Prompt = strings.TrimSpace(prompt)
+
+		file, err := c.addCommand(cmd, source)
+		if err != nil {
+			return nil, err
+		}
+		if file == "" {
+			continue
+		}
+		files = append(files, file)
+
+		preview := domain.CommandPreview{Source: source, Target: "commands/" + cmd.Name + ".toml", Prompt: cmd.Prompt}
+		for _, r := range rewrites {
+			if r.Lossy {
+				preview.Notes = append(preview.Notes, r.String())
+				c.warnings = append(c.warnings, fmt.Sprintf("commands/%s.toml: %s", cmd.Name, r))
+			}
+		}
+		c.previews = append(c.previews, preview)
+	}
+
+	return files, nil
+}
+
+// addCommand adds the command converted from source to the plan. A command
+// whose file another source already produced is skipped and reported, and ""
+// is returned.
+func (c *ClaudeToGeminiConverter) addCommand(cmd *GeminiCommand, source string) (string, error) {
+	rel := "commands/" + cmd.Name + ".toml"
+	if first, ok := c.sources[rel]; ok {
+		c.warnings = append(c.warnings, fmt.Sprintf("%s: %s and %s both become %s; skipped %s",
+			rel, first, source, commandInvocation(cmd.Name), source))
+		return "", nil
+	}
+	if c.sources == nil {
+		c.sources = map[string]string{}
+	}
+	c.sources[rel] = source
+
+	data, err := cmd.MarshalTOML()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", rel, err)
+	}
+	return c.plan.Add(rel, data, 0644), nil
+}
+
+// injectDocs copies the Gemini architecture guide into docs/. Like the Node
+// CLI, the template is resolved relative to the working directory.
+func (c *ClaudeToGeminiConverter) injectDocs() {
+	content, err := os.ReadFile(filepath.Join("templates", "GEMINI_ARCH_GUIDE.md"))
+	if err != nil {
+		content = []byte("# Gemini Architecture\n\nSee online documentation.")
+	}
+	c.plan.Add("docs/GEMINI_ARCHITECTURE.md", content, 0644)
+}
+
+// firstPlugin returns plugins[0] of a marketplace.json, or nil.
+func firstPlugin(marketplace *jsonobj.Object) *jsonobj.Object {
+	v, _ := marketplace.Get("plugins")
+	plugins, ok := v.([]any)
+	if !ok || len(plugins) == 0 {
+		return nil
+	}
+	plugin, _ := plugins[0].(*jsonobj.Object)
+	return plugin
+}
+
+// isTruthy mirrors JavaScript truthiness for values decoded from JSON.
+func isTruthy(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		return t != ""
+	case json.Number:
+		return t.String() != "0"
+	default:
+		return true
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
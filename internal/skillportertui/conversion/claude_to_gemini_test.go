@@ -0,0 +1,413 @@
+package conversion
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/settings"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+)
+
+const fixturesDir = "../../../examples/before-after"
+
+func copyFixture(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertSameFile(t *testing.T, want, got string) {
+	t.Helper()
+	wantData, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatalf("read golden %s: %v", want, err)
+	}
+	gotData, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatalf("read output %s: %v", got, err)
+	}
+	if string(wantData) != string(gotData) {
+		t.Errorf("%s differs from golden %s\n--- want\n%s\n--- got\n%s", filepath.Base(got), want, wantData, gotData)
+	}
+}
+
+// assertSameManifest checks a generated gemini-extension.json against one
+// written by the Node CLI. Every field must match except the tool lists: the
+// CLI copies Claude's names into excludeTools, while the Go converter writes
+// Gemini's names and keeps Claude-only tools in claudeExcludeTools. Both must
+// still exclude the same Claude tools.
+func assertSameManifest(t *testing.T, want, got string) {
+	t.Helper()
+	var wantManifest, gotManifest map[string]any
+	for path, m := range map[string]*map[string]any{want: &wantManifest, got: &gotManifest} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if err := json.Unmarshal(data, m); err != nil {
+			t.Fatalf("invalid %s: %v", path, err)
+		}
+	}
+
+	catalog := tools.Default()
+	var wantTools, gotTools []string
+	for _, name := range stringList(wantManifest["excludeTools"]) {
+		if tool, ok := catalog.Lookup(domain.PlatformClaude, name); ok {
+			wantTools = append(wantTools, toolKey(tool))
+		}
+	}
+	for _, name := range stringList(gotManifest["excludeTools"]) {
+		// The CLI only knows Claude's tools, so it never excludes Gemini-only ones
+		if tool, ok := catalog.Lookup(domain.PlatformGemini, name); ok && tool.Claude != "" {
+			gotTools = append(gotTools, toolKey(tool))
+		}
+	}
+	for _, name := range stringList(gotManifest[claudeExcludeToolsKey]) {
+		if tool, ok := catalog.Lookup(domain.PlatformClaude, name); ok {
+			gotTools = append(gotTools, toolKey(tool))
+		}
+	}
+	sort.Strings(wantTools)
+	sort.Strings(gotTools)
+	if !reflect.DeepEqual(wantTools, gotTools) {
+		t.Errorf("Expected the same excluded tools as %s, got excludeTools %v and %s %v", want,
+			gotManifest["excludeTools"], claudeExcludeToolsKey, gotManifest[claudeExcludeToolsKey])
+	}
+
+	for _, m := range []map[string]any{wantManifest, gotManifest} {
+		delete(m, "excludeTools")
+		delete(m, claudeExcludeToolsKey)
+	}
+	if !reflect.DeepEqual(wantManifest, gotManifest) {
+		t.Errorf("gemini-extension.json differs from %s apart from its tools\n--- want\n%v\n--- got\n%v", want, wantManifest, gotManifest)
+	}
+}
+
+func TestClaudeToGemini_MatchesFixture(t *testing.T) {
+	fixture := filepath.Join(fixturesDir, "code-formatter-converted")
+	src := t.TempDir()
+	copyFixture(t, filepath.Join(fixture, "SKILL.md"), filepath.Join(src, "SKILL.md"))
+	copyFixture(t, filepath.Join(fixture, ".claude-plugin", "marketplace.json"), filepath.Join(src, ".claude-plugin", "marketplace.json"))
+
+	out := filepath.Join(t.TempDir(), "code-formatter")
+	result, err := NewClaudeToGeminiConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(result.Files) != 2 {
+		t.Errorf("Expected 2 generated files, got %v", result.Files)
+	}
+
+	assertSameManifest(t, filepath.Join(fixture, "gemini-extension.json"), filepath.Join(out, "gemini-extension.json"))
+	assertSameFile(t, filepath.Join(fixture, "GEMINI.md"), filepath.Join(out, "GEMINI.md"))
+
+	for _, f := range []string{"shared/reference.md", "shared/examples.md", "docs/GEMINI_ARCHITECTURE.md"} {
+		if !fileExists(filepath.Join(out, f)) {
+			t.Errorf("Expected %s to be created", f)
+		}
+	}
+}
+
+func TestClaudeToGemini_Commands(t *testing.T) {
+	src := t.TempDir()
+	skill := "---\nname: reviewer-skill\ndescription: Reviews code\nsubagents:\n  - name: reviewer\n    description: You are a senior code reviewer.\n---\n\n# Body\n"
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte(skill), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "fix.md"),
+		[]byte("---\ndescription: Fix an issue\n---\nFix issue $1 using $ARGUMENTS\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "notes.txt"), []byte("ignored"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(result.Files) != 4 {
+		t.Errorf("Expected 4 generated files, got %v", result.Files)
+	}
+
+	agent, err := os.ReadFile(filepath.Join(src, "commands", "reviewer.toml"))
+	if err != nil {
+		t.Fatalf("subagent command not written: %v", err)
+	}
+	if !strings.Contains(string(agent), "You are acting as the 'reviewer' agent.\nYou are a senior code reviewer.") {
+		t.Errorf("Unexpected subagent command:\n%s", agent)
+	}
+
+	fix, err := os.ReadFile(filepath.Join(src, "commands", "fix.toml"))
+	if err != nil {
+		t.Fatalf("slash command not written: %v", err)
+	}
+	want := `description = "Fix an issue"
+
+prompt = """
+Arguments: {{args}}
+
+Split the arguments above on spaces, keeping quoted text together as one argument. In this prompt:
+- <arg1> is argument 1
+
+Fix issue <arg1> using {{args}}
+"""
+`
+	if string(fix) != want {
+		t.Errorf("Unexpected command TOML:\ngot  %q\nwant %q", fix, want)
+	}
+}
+
+func TestClaudeToGemini_CommandEscaping(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "grep.md"),
+		[]byte("---\ndescription: 'Search for \"quoted\" text'\nargument-hint: <pattern>\ncategory: search\n---\nRun grep -E '\\d+' on $ARGUMENTS\n"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(src, "commands", "grep.toml"))
+	cmd, err := ParseGeminiCommand("grep", data)
+	if err != nil {
+		t.Fatalf("Generated invalid TOML: %v\n%s", err, data)
+	}
+	if cmd.Description != `Search for "quoted" text` {
+		t.Errorf("Unexpected description %q", cmd.Description)
+	}
+	if cmd.Prompt != "Run grep -E '\\d+' on {{args}}\n" {
+		t.Errorf("Unexpected prompt %q", cmd.Prompt)
+	}
+
+	// argument-hint is carried over; other keys Claude does not define are
+	// kept and reported
+	if keys := cmd.UnknownKeys(); strings.Join(keys, ",") != "argument-hint,category" {
+		t.Errorf("Expected argument-hint and category to be carried over, got %v", keys)
+	}
+	found := false
+	for _, w := range result.Warnings {
+		found = found || strings.Contains(w, "category")
+		if strings.Contains(w, "argument-hint") {
+			t.Errorf("Expected no warning about argument-hint, got %q", w)
+		}
+	}
+	if !found {
+		t.Errorf("Expected a warning about the kept key, got %v", result.Warnings)
+	}
+}
+
+func TestClaudeToGemini_MissingFrontmatter(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("# No frontmatter\n"), 0644)
+
+	if _, err := NewClaudeToGeminiConverter(src, "").Convert(); err == nil {
+		t.Error("Expected error for SKILL.md without frontmatter, got nil")
+	}
+}
+
+func TestClaudeToGemini_Tools(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"),
+		[]byte("---\nname: demo\ndescription: Demo skill\nallowed-tools: Read, Grep, Teleport, mcp__db__query, mcp__db__schema, mcp__cache__get\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude-plugin", "marketplace.json"),
+		[]byte(`{"plugins": [{"name": "demo", "mcpServers": {"db": {"command": "db-mcp"}}}]}`), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var manifest struct {
+		ExcludeTools       []string `json:"excludeTools"`
+		ClaudeExcludeTools []string `json:"claudeExcludeTools"`
+		MCPServers         map[string]struct {
+			IncludeTools []string `json:"includeTools"`
+		} `json:"mcpServers"`
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "gemini-extension.json"))
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Invalid manifest: %v", err)
+	}
+
+	for _, tool := range []string{"run_shell_command", "replace", "list_directory"} {
+		if !containsString(manifest.ExcludeTools, tool) {
+			t.Errorf("Expected %s to be excluded, got %v", tool, manifest.ExcludeTools)
+		}
+	}
+	// Gemini does not know Claude-only tools; they are carried separately
+	if containsString(manifest.ExcludeTools, "Task") || !containsString(manifest.ClaudeExcludeTools, "Task") {
+		t.Errorf("Expected Task in claudeExcludeTools only, got %v and %v", manifest.ExcludeTools, manifest.ClaudeExcludeTools)
+	}
+	if len(result.Lossy) != 1 || !strings.Contains(result.Lossy[0].Value, "Task") {
+		t.Errorf("Expected the Claude-only exclusions in the loss report, got %v", result.Lossy)
+	}
+	for _, tool := range []string{"read_file", "Read", "search_file_content", "Grep"} {
+		if containsString(manifest.ExcludeTools, tool) {
+			t.Errorf("Expected allowed tool %s not to be excluded", tool)
+		}
+	}
+	if got := manifest.MCPServers["db"].IncludeTools; strings.Join(got, ",") != "query,schema" {
+		t.Errorf("Expected db includeTools [query schema], got %v", got)
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, want := range []string{`unknown tool "Teleport"`, `MCP server "cache" is not configured`} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected a warning containing %q, got %v", want, result.Warnings)
+		}
+	}
+}
+
+func TestClaudeToGemini_ScopedTools(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"),
+		[]byte("---\nname: demo\ndescription: Demo skill\nallowed-tools: Bash(git diff:*), Read(./src/**), Grep\n---\n\nBody\n"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var manifest struct {
+		ExcludeTools []string `json:"excludeTools"`
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "gemini-extension.json"))
+	json.Unmarshal(data, &manifest)
+	for _, tool := range []string{"run_shell_command", "read_file", "search_file_content"} {
+		if containsString(manifest.ExcludeTools, tool) {
+			t.Errorf("Expected allowed tool %s not to be excluded, got %v", tool, manifest.ExcludeTools)
+		}
+	}
+	if strings.Contains(strings.Join(result.Warnings, "\n"), "unknown tool") {
+		t.Errorf("Expected scoped tools to be recognised, got %v", result.Warnings)
+	}
+
+	var narrowed []string
+	for _, l := range result.Lossy {
+		if l.Field == "allowed-tools" && strings.Contains(l.Value, "(") {
+			narrowed = append(narrowed, l.Value)
+		}
+	}
+	if strings.Join(narrowed, ", ") != "Bash(git diff:*), Read(./src/**)" {
+		t.Errorf("Expected both scopes in the loss report, got %v", result.Lossy)
+	}
+}
+
+func TestClaudeToGemini_ToolCatalogOption(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\nallowed-tools: Read\n---\n\nBody\n"), 0644)
+	catalogFile := filepath.Join(t.TempDir(), "tools.yaml")
+	os.WriteFile(catalogFile, []byte("version: 1\ntools:\n  - claude: Teleport\n    gemini: teleport\n"), 0644)
+	catalog, err := tools.Load(catalogFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	c := NewClaudeToGeminiConverter(src, "")
+	c.Options = Options{Tools: catalog}
+	if _, err := c.Convert(); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	var manifest struct {
+		ExcludeTools []string `json:"excludeTools"`
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "gemini-extension.json"))
+	json.Unmarshal(data, &manifest)
+	if !containsString(manifest.ExcludeTools, "teleport") {
+		t.Errorf("Expected the catalog's teleport to be excluded, got %v", manifest.ExcludeTools)
+	}
+}
+
+func TestClaudeToGemini_SettingsRulesOption(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".mcp.json"), []byte(`{"mcpServers": {"team": {"command": "team-mcp", "env": {"TOKEN": "${TEAM_TOKEN}"}}}}`), 0644)
+	rulesFile := filepath.Join(t.TempDir(), "settings.yaml")
+	os.WriteFile(rulesFile, []byte("version: 1\nrules:\n  - match: TEAM_TOKEN\n    description: Team access token\n"), 0644)
+	rules, err := settings.Load(rulesFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	c := NewClaudeToGeminiConverter(src, "")
+	c.Options = Options{Settings: rules}
+	result, err := c.Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(result.Settings) != 1 || result.Settings[0].Description != "Team access token" {
+		t.Errorf("Expected the rule's description, got %+v", result.Settings)
+	}
+}
+
+func TestClaudeToGemini_MCPConfig(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude-plugin", "marketplace.json"),
+		[]byte(`{"plugins": [{"name": "demo", "mcpServers": {"db": {"command": "node", "args": ["server/db.js"]}}}]}`), 0644)
+	os.WriteFile(filepath.Join(src, ".mcp.json"), []byte(`{"mcpServers": {
+		"db": {"command": "ignored"},
+		"api": {"command": "${CLAUDE_PLUGIN_ROOT}/bin/api", "env": {"API_URL": "${API_URL:-http://localhost:8080}"}},
+		"remote": {"type": "http", "url": "https://example.com/mcp"}
+	}}`), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(src, "gemini-extension.json"))
+	manifest := string(data)
+	for _, want := range []string{
+		`"${extensionPath}/server/db.js"`,
+		`"command": "${extensionPath}/bin/api"`,
+		`"API_URL": "${API_URL}"`,
+		`"httpUrl": "https://example.com/mcp"`,
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("Expected manifest to contain %s:\n%s", want, manifest)
+		}
+	}
+	if strings.Contains(manifest, "ignored") {
+		t.Errorf("Expected the marketplace definition of db to win:\n%s", manifest)
+	}
+
+	if len(result.Settings) != 1 || result.Settings[0].Name != "API_URL" || strings.Join(result.Settings[0].Servers, ",") != "api" {
+		t.Errorf("Expected API_URL to be inferred from the api server, got %+v", result.Settings)
+	}
+	if len(result.MCPRewrites) != 6 {
+		t.Errorf("Expected 6 MCP rewrites, got %d: %v", len(result.MCPRewrites), result.MCPRewrites)
+	}
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, want := range []string{`MCP server "db" is defined in both`, "mcpServers.api.env.API_URL"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected a warning containing %q, got %v", want, result.Warnings)
+		}
+	}
+}
+
+func TestClaudeToGemini_InvalidMCPConfig(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".mcp.json"), []byte("{not json"), 0644)
+
+	if _, err := NewClaudeToGeminiConverter(src, "").Convert(); err == nil || !strings.Contains(err.Error(), "invalid .mcp.json") {
+		t.Errorf("Expected an invalid .mcp.json error, got %v", err)
+	}
+}
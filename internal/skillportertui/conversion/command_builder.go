@@ -23,6 +23,11 @@ func BuildConvertCommand(inputPath string, target domain.ConversionTarget, outpu
 		if outputDir != "" {
 			args = append(args, "--output", outputDir)
 		}
+	case "universal":
+		args = append(args, "universal", inputPath)
+		if outputDir != "" {
+			args = append(args, "--output", outputDir)
+		}
 	case "auto":
 		return nil, fmt.Errorf("cannot build command for 'Auto' target; must be resolved")
 	default:
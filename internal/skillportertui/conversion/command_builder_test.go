@@ -31,6 +31,14 @@ func TestBuildConvertCommand(t *testing.T) {
 			wantArgs: []string{"convert", "/abs/skill", "--to", "claude"},
 			wantErr:  false,
 		},
+		{
+			name:     "Universal With Output",
+			input:    "./skill",
+			target:   domain.TargetUniversal,
+			output:   "./out",
+			wantArgs: []string{"universal", "./skill", "--output", "./out"},
+			wantErr:  false,
+		},
 		{
 			name:    "Auto Target Error",
 			input:   "./skill",
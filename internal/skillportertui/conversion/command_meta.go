@@ -0,0 +1,85 @@
+package conversion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// A Gemini command only reads description and prompt. Of the frontmatter a
+// Claude slash command can have, description maps directly, argument-hint
+// names the arguments in the translated prompt, and disable-model-invocation
+// true is what Gemini does anyway, since only the user runs its commands.
+// allowed-tools and model have no equivalent and go into the loss report.
+// All four are also kept as extra TOML keys, which Gemini ignores, so
+// converting back restores them.
+
+// lossyCommandReasons explains why the command keys Claude Code defines are
+// lost in a Gemini command.
+var lossyCommandReasons = map[string]string{
+	"allowed-tools":            "Gemini commands cannot restrict tools; the command runs with the extension's tools",
+	"model":                    "Gemini commands cannot choose a model; the command runs on the session's model",
+	"disable-model-invocation": "Gemini commands can only be run by the user, never by the model",
+}
+
+// mapCommandFrontmatter maps the frontmatter of the slash command at source
+// onto cmd, records the fields Gemini cannot honour, and returns the
+// argument-hint. Every key but description is kept as an extra TOML key.
+func (c *ClaudeToGeminiConverter) mapCommandFrontmatter(cmd *GeminiCommand, source string, entries []yamlEntry) (argumentHint string) {
+	var kept []string
+	for _, e := range entries {
+		if e.Value == nil {
+			continue
+		}
+		switch e.Key {
+		case "description":
+			if d, ok := e.Value.(string); ok && d != "" {
+				cmd.Description = d
+			}
+		case "argument-hint":
+			if h := hintString(e.Value); h != "" {
+				cmd.SetExtra(e.Key, h)
+				argumentHint = h
+			}
+		case "disable-model-invocation":
+			cmd.SetExtra(e.Key, e.Value)
+			if disabled, ok := e.Value.(bool); !ok || !disabled {
+				c.dropCommandField(source, e)
+			}
+		case "allowed-tools", "model":
+			cmd.SetExtra(e.Key, e.Value)
+			c.dropCommandField(source, e)
+		default:
+			cmd.SetExtra(e.Key, e.Value)
+			kept = append(kept, e.Key)
+		}
+	}
+	if len(kept) > 0 {
+		c.warnings = append(c.warnings, fmt.Sprintf("commands/%s.toml: kept unknown frontmatter key(s) %s from %s",
+			cmd.Name, strings.Join(kept, ", "), source))
+	}
+	return argumentHint
+}
+
+// hintString returns an argument-hint as the text Claude Code shows. Left
+// unquoted, the usual "[message]" parses as a YAML list, so each item is put
+// back in its brackets.
+func hintString(v any) string {
+	switch h := v.(type) {
+	case string:
+		return h
+	case []any:
+		items := make([]string, len(h))
+		for i, item := range h {
+			items[i] = "[" + fmt.Sprint(item) + "]"
+		}
+		return strings.Join(items, " ")
+	}
+	return ""
+}
+
+// dropCommandField records a command frontmatter field in the loss report.
+func (c *ClaudeToGeminiConverter) dropCommandField(source string, e yamlEntry) {
+	c.lossy = append(c.lossy, domain.LossyField{File: source, Field: e.Key, Value: fieldValue(e.Value), Reason: lossyCommandReasons[e.Key]})
+}
@@ -0,0 +1,178 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestClaudeToGemini_CommandFrontmatter(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		carried string // Extra TOML keys, in order
+		lossy   []string
+	}{
+		{
+			name:    "Every Claude key",
+			fields:  "allowed-tools: Bash(git diff:*), Read\nargument-hint: <file>\nmodel: opus\ndisable-model-invocation: true\n",
+			carried: "allowed-tools,argument-hint,model,disable-model-invocation",
+			lossy: []string{
+				".claude/commands/review.md: allowed-tools = Bash(git diff:*), Read",
+				".claude/commands/review.md: model = opus",
+			},
+		},
+		{
+			name:    "Model invocation allowed",
+			fields:  "disable-model-invocation: false\n",
+			carried: "disable-model-invocation",
+			lossy:   []string{".claude/commands/review.md: disable-model-invocation = false"},
+		},
+		{
+			name:    "Tool list and empty model",
+			fields:  "allowed-tools:\n  - Read\n  - Grep\nmodel:\n",
+			carried: "allowed-tools",
+			lossy:   []string{".claude/commands/review.md: allowed-tools = Read, Grep"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := t.TempDir()
+			os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+			os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+			os.WriteFile(filepath.Join(src, ".claude", "commands", "review.md"),
+				[]byte("---\ndescription: Review a file\n"+tt.fields+"---\n\nReview $ARGUMENTS\n"), 0644)
+
+			result, err := NewClaudeToGeminiConverter(src, "").Convert()
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+
+			data, _ := os.ReadFile(filepath.Join(src, "commands", "review.toml"))
+			cmd, err := ParseGeminiCommand("review", data)
+			if err != nil {
+				t.Fatalf("Generated invalid TOML: %v\n%s", err, data)
+			}
+			if cmd.Description != "Review a file" {
+				t.Errorf("Unexpected description %q", cmd.Description)
+			}
+			if keys := strings.Join(cmd.UnknownKeys(), ","); keys != tt.carried {
+				t.Errorf("Expected carried keys %q, got %q", tt.carried, keys)
+			}
+
+			if len(result.Lossy) != len(tt.lossy) {
+				t.Fatalf("Expected %d lossy field(s), got %v", len(tt.lossy), result.Lossy)
+			}
+			for i, want := range tt.lossy {
+				if got := result.Lossy[i].String(); !strings.HasPrefix(got, want+" (") {
+					t.Errorf("Expected lossy field %q, got %q", want, got)
+				}
+			}
+			if len(result.Warnings) != 0 {
+				t.Errorf("Expected no warnings, got %v", result.Warnings)
+			}
+		})
+	}
+}
+
+func TestClaudeToGemini_CommandArgumentHint(t *testing.T) {
+	tests := []struct {
+		hint string
+		want string
+	}{
+		{"<file>", "<file>"},
+		{"'[message]'", "[message]"},
+		{"[message]", "[message]"},
+		{"[pr, priority]", "[pr] [priority]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hint, func(t *testing.T) {
+			src := t.TempDir()
+			os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+			os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+			os.WriteFile(filepath.Join(src, ".claude", "commands", "commit.md"),
+				[]byte("---\nargument-hint: "+tt.hint+"\n---\n\nCommit $ARGUMENTS\n"), 0644)
+
+			if _, err := NewClaudeToGeminiConverter(src, "").Convert(); err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			data, _ := os.ReadFile(filepath.Join(src, "commands", "commit.toml"))
+			cmd, err := ParseGeminiCommand("commit", data)
+			if err != nil {
+				t.Fatalf("Generated invalid TOML: %v\n%s", err, data)
+			}
+			if got := cmd.Extra["argument-hint"]; got != tt.want {
+				t.Errorf("Expected argument-hint %q, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClaudeToGemini_InvalidCommandFrontmatter(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "triage.md"),
+		[]byte("---\ndescription: Triage a PR\nargument-hint: [pr] [priority]\n---\n\nTriage $ARGUMENTS\n"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "commands", "triage.toml"))
+	cmd, err := ParseGeminiCommand("triage", data)
+	if err != nil {
+		t.Fatalf("Generated invalid TOML: %v\n%s", err, data)
+	}
+	if strings.TrimSpace(cmd.Prompt) != "Triage {{args}}" {
+		t.Errorf("Expected the frontmatter to be left out of the prompt, got %q", cmd.Prompt)
+	}
+	if len(result.Lossy) != 1 || result.Lossy[0].File != ".claude/commands/triage.md" || result.Lossy[0].Field != "frontmatter" {
+		t.Errorf("Expected the frontmatter to be reported as lost, got %v", result.Lossy)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "commands/triage.toml: invalid frontmatter in .claude/commands/triage.md was dropped") {
+		t.Errorf("Expected a warning about the frontmatter, got %v", result.Warnings)
+	}
+}
+
+func TestRoundtrip_CommandFrontmatter(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "review.md"),
+		[]byte("---\ndescription: Review a file\nallowed-tools: Read, Grep\nargument-hint: <file>\nmodel: opus\ndisable-model-invocation: true\n---\n\nReview $ARGUMENTS\n"), 0644)
+
+	report, err := Roundtrip(src, Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	for _, key := range []string{"description", "allowed-tools", "argument-hint", "model", "disable-model-invocation"} {
+		if f := findFinding(report.Findings, "field-lost", "/review: "+key); f != nil {
+			t.Errorf("Expected %s to survive, got %v", key, f)
+		}
+	}
+}
+
+func TestFormatLossReport(t *testing.T) {
+	result := &Result{
+		Source: domain.PlatformClaude,
+		Lossy: []domain.LossyField{
+			{File: ".claude/commands/review.md", Field: "model", Value: "opus", Reason: "no models"},
+		},
+	}
+	want := "demo: Claude → Gemini, 1 field(s) dropped\n" +
+		"  - .claude/commands/review.md: model = opus (no models)\n"
+	if got := FormatLossReport("demo", domain.TargetGemini, result); got != want {
+		t.Errorf("Unexpected report:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	skipped := &Result{Message: "Already a claude skill - no conversion needed"}
+	if got := FormatLossReport("demo", domain.TargetClaude, skipped); got != "demo: Already a claude skill - no conversion needed\n" {
+		t.Errorf("Unexpected report for a skipped skill: %q", got)
+	}
+}
@@ -0,0 +1,211 @@
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// SkillPorterCommand is the Node CLI used by the subprocess backend.
+const SkillPorterCommand = "skill-porter"
+
+// Converter converts a skill directory to a target platform.
+// An empty outDir converts in place.
+type Converter interface {
+	Convert(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error)
+}
+
+// Planner is implemented by converters that can stage a conversion without
+// writing it, so the output can be reviewed before the plan is applied.
+type Planner interface {
+	Plan(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error)
+}
+
+// NewConverter returns the Converter implementation for backend. When
+// backups is set, in-place conversions are snapshotted into it first. opts
+// only applies to the native backend.
+func NewConverter(backend domain.ConversionBackend, backups *BackupStore, opts Options) (Converter, error) {
+	switch backend {
+	case domain.BackendNative, "":
+		return NativeConverter{Backups: backups, Options: opts}, nil
+	case domain.BackendSubprocess:
+		return SubprocessConverter{Command: SkillPorterCommand, Backups: backups}, nil
+	default:
+		return nil, fmt.Errorf("unsupported backend: %s", backend)
+	}
+}
+
+// NativeConverter runs the Go converters in-process.
+type NativeConverter struct {
+	Backups *BackupStore
+	Options Options
+}
+
+func (c NativeConverter) Convert(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := PlanConversion(skill.Path, target, outDir, c.Options)
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyPlan(ctx, result.Plan, skill.Path, outDir, c.Backups); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c NativeConverter) Plan(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return PlanConversion(skill.Path, target, outDir, c.Options)
+}
+
+// SubprocessConverter shells out to the skill-porter CLI in --json mode. The
+// CLI writes into a temporary directory whose contents are only moved into
+// place once it has exited successfully.
+type SubprocessConverter struct {
+	Command string
+	Backups *BackupStore
+}
+
+func (c SubprocessConverter) Convert(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error) {
+	result, err := c.Plan(ctx, skill, target, outDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyPlan(ctx, result.Plan, skill.Path, outDir, c.Backups); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Plan runs the CLI into a temporary directory seeded with a copy of the
+// destination, so that files it only creates when missing, such as
+// shared/reference.md, are left alone as they would be in place. Only the
+// files the CLI added or changed are planned. The CLI does not know Claude
+// plugins, so they are rejected before it runs.
+func (c SubprocessConverter) Plan(ctx context.Context, skill domain.SkillDir, target domain.ConversionTarget, outDir string) (*Result, error) {
+	if !fileExists(filepath.Join(skill.Path, "SKILL.md")) && isClaudePlugin(skill.Path) {
+		return nil, fmt.Errorf("%s is a Claude plugin, which the skill-porter CLI cannot convert; use the native backend", skill.Path)
+	}
+
+	stage, err := os.MkdirTemp("", stagePrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stage)
+
+	dest := outDir
+	if dest == "" {
+		dest = skill.Path
+	}
+	seed := NewPlan(stage)
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		if err := seed.addTree(dest, ".", false); err != nil {
+			return nil, fmt.Errorf("copy %s to staging directory: %w", dest, err)
+		}
+	}
+	// Like makeUniversal, universal output elsewhere holds the skill as well
+	if target == domain.TargetUniversal && filepath.Clean(dest) != filepath.Clean(skill.Path) {
+		if err := seed.addTree(skill.Path, ".", false); err != nil {
+			return nil, fmt.Errorf("copy skill to staging directory: %w", err)
+		}
+	}
+	if err := seed.ApplyContext(ctx); err != nil {
+		return nil, err
+	}
+
+	args, err := BuildConvertCommand(skill.Path, target, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	output, runErr := ExecuteCommand(ctx, c.Command, append(args, "--json"))
+	cli, err := parseCLIResult(output)
+	if runErr != nil {
+		if err == nil && len(cli.Errors) > 0 {
+			return nil, fmt.Errorf("skill-porter: %s", strings.Join(cli.Errors, "; "))
+		}
+		return nil, runErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plan := NewPlan(dest)
+	if err := plan.addTree(stage, ".", false); err != nil {
+		return nil, err
+	}
+	for _, f := range append([]PlannedFile(nil), plan.Files()...) {
+		if existing, err := os.ReadFile(plan.Abs(f.Path)); err == nil && bytes.Equal(existing, f.Content) {
+			plan.Remove(f.Path)
+		}
+	}
+
+	return cli.result(stage, plan), nil
+}
+
+// cliResult is what `skill-porter convert --json` and `universal --json` print.
+type cliResult struct {
+	Success        bool     `json:"success"`
+	SourcePlatform string   `json:"sourcePlatform"`
+	Message        string   `json:"message"`
+	Files          []string `json:"files"`
+	Warnings       []string `json:"warnings"`
+	Errors         []string `json:"errors"`
+	Validation     *struct {
+		Warnings []string `json:"warnings"`
+	} `json:"validation"`
+}
+
+func parseCLIResult(output string) (*cliResult, error) {
+	var r cliResult
+	if err := json.Unmarshal([]byte(output), &r); err != nil {
+		return nil, fmt.Errorf("unexpected skill-porter output (is the CLI too old for --json?): %w", err)
+	}
+	return &r, nil
+}
+
+// result maps the CLI's report onto a Result. Files the CLI wrote into the
+// staging directory are reported at their final location.
+func (r *cliResult) result(stage string, plan *Plan) *Result {
+	result := &Result{Source: cliPlatform(r.SourcePlatform), Warnings: r.Warnings, Plan: plan}
+	for _, f := range r.Files {
+		if rel, err := filepath.Rel(stage, f); err == nil && !strings.HasPrefix(rel, "..") {
+			f = plan.Abs(filepath.ToSlash(rel))
+		}
+		result.Files = append(result.Files, f)
+	}
+	if r.Validation != nil {
+		for _, w := range r.Validation.Warnings {
+			result.Warnings = append(result.Warnings, "validation: "+w)
+		}
+	}
+	// The CLI also reports a message on success; it only means nothing was
+	// converted when no files were written
+	if len(result.Files) == 0 {
+		result.Message = r.Message
+	}
+	return result
+}
+
+// cliPlatform maps the CLI's lowercase platform names onto Platform*.
+func cliPlatform(p string) string {
+	switch p {
+	case "claude":
+		return domain.PlatformClaude
+	case "gemini":
+		return domain.PlatformGemini
+	case "universal":
+		return domain.PlatformUniversal
+	}
+	return p
+}
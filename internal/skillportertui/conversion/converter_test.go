@@ -0,0 +1,215 @@
+package conversion
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestNewConverter(t *testing.T) {
+	if c, err := NewConverter(domain.BackendNative, nil, Options{}); err != nil {
+		t.Errorf("native backend: %v", err)
+	} else if _, ok := c.(NativeConverter); !ok {
+		t.Errorf("Expected NativeConverter, got %T", c)
+	}
+
+	if c, err := NewConverter(domain.BackendSubprocess, nil, Options{}); err != nil {
+		t.Errorf("subprocess backend: %v", err)
+	} else if _, ok := c.(SubprocessConverter); !ok {
+		t.Errorf("Expected SubprocessConverter, got %T", c)
+	}
+
+	if _, err := NewConverter("bogus", nil, Options{}); err == nil {
+		t.Error("Expected error for unknown backend, got nil")
+	}
+}
+
+func TestNativeConverter_Convert(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+
+	skill := domain.SkillDir{Name: "demo", Path: dir, CurrentPlatform: "Claude"}
+	out := filepath.Join(t.TempDir(), "demo")
+	result, err := NativeConverter{}.Convert(context.Background(), skill, domain.TargetGemini, out)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(result.Files) == 0 || !fileExists(filepath.Join(out, "gemini-extension.json")) {
+		t.Errorf("Expected gemini-extension.json in %s, got files %v", out, result.Files)
+	}
+	if result.Source != domain.PlatformClaude {
+		t.Errorf("Expected source platform Claude, got %q", result.Source)
+	}
+}
+
+func TestNativeConverter_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NativeConverter{}.Convert(ctx, domain.SkillDir{Path: t.TempDir()}, domain.TargetGemini, "")
+	if err == nil {
+		t.Error("Expected error for cancelled context, got nil")
+	}
+}
+
+func TestSubprocessConverter_NonJSONOutput(t *testing.T) {
+	// 'echo' stands in for a CLI without --json and echoes the built arguments
+	c := SubprocessConverter{Command: "echo"}
+	_, err := c.Convert(context.Background(), domain.SkillDir{Path: "/abs/skill"}, domain.TargetClaude, "")
+	if err == nil || !strings.Contains(err.Error(), "--json") {
+		t.Errorf("Expected an error about the CLI output, got %v", err)
+	}
+}
+
+// fakeCLI writes a shell script standing in for skill-porter. It writes two
+// files into the --output directory, prints a JSON result and then exits
+// with status.
+func fakeCLI(t *testing.T, status int) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "skill-porter")
+	body := fmt.Sprintf(`#!/bin/sh
+out="$6"
+mkdir -p "$out/commands"
+echo '{"name": "demo"}' > "$out/gemini-extension.json"
+echo 'prompt = ""' > "$out/commands/demo.toml"
+if [ %[1]d -eq 0 ]; then
+  echo '{"success": true, "sourcePlatform": "claude", "message": "Conversion successful",'
+  echo ' "files": ["'"$out"'/gemini-extension.json", "'"$out"'/commands/demo.toml"],'
+  echo ' "warnings": ["Tool restrictions may not translate exactly"],'
+  echo ' "validation": {"valid": true, "errors": [], "warnings": ["No commands documented"]}}'
+else
+  echo '{"success": false, "errors": ["Validation failed", "Missing description"]}'
+fi
+exit %[1]d
+`, status)
+	os.WriteFile(script, []byte(body), 0755)
+	return script
+}
+
+func TestSubprocessConverter_CommitsOnSuccess(t *testing.T) {
+	src := t.TempDir()
+	c := SubprocessConverter{Command: fakeCLI(t, 0)}
+
+	result, err := c.Convert(context.Background(), domain.SkillDir{Path: src}, domain.TargetGemini, "")
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	for _, f := range []string{"gemini-extension.json", "commands/demo.toml"} {
+		if !fileExists(filepath.Join(src, f)) {
+			t.Errorf("Expected %s to be committed into the skill", f)
+		}
+	}
+
+	// Files are reported where they ended up, not in the staging directory
+	want := []string{filepath.Join(src, "gemini-extension.json"), filepath.Join(src, "commands", "demo.toml")}
+	if strings.Join(result.Files, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected files %v, got %v", want, result.Files)
+	}
+	if result.Source != domain.PlatformClaude {
+		t.Errorf("Expected source platform Claude, got %q", result.Source)
+	}
+	if result.Message != "" {
+		t.Errorf("Expected no 'no conversion needed' message, got %q", result.Message)
+	}
+	if len(result.Warnings) != 2 || result.Warnings[1] != "validation: No commands documented" {
+		t.Errorf("Expected conversion and validation warnings, got %v", result.Warnings)
+	}
+}
+
+func TestSubprocessConverter_FailureLeavesNothing(t *testing.T) {
+	src := t.TempDir()
+	c := SubprocessConverter{Command: fakeCLI(t, 1)}
+
+	_, err := c.Convert(context.Background(), domain.SkillDir{Path: src}, domain.TargetGemini, "")
+	if err == nil {
+		t.Fatal("Expected error from failing CLI, got nil")
+	}
+	if !strings.Contains(err.Error(), "Missing description") {
+		t.Errorf("Expected the CLI's errors in the error, got %v", err)
+	}
+	entries, _ := os.ReadDir(src)
+	if len(entries) != 0 {
+		t.Errorf("Expected failed conversion to leave the skill untouched, found %d entries", len(entries))
+	}
+}
+
+func TestSubprocessConverter_RejectsPlugins(t *testing.T) {
+	src := writePlugin(t)
+	c := SubprocessConverter{Command: fakeCLI(t, 0)}
+
+	_, err := c.Convert(context.Background(), domain.SkillDir{Path: src}, domain.TargetGemini, "")
+	if err == nil || !strings.Contains(err.Error(), "use the native backend") {
+		t.Fatalf("Expected plugins to be rejected, got %v", err)
+	}
+	if fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Expected the plugin to be left untouched")
+	}
+}
+
+// sharedCLI writes a shell script standing in for skill-porter that, like
+// its _ensureSharedStructure, only creates shared/reference.md when the
+// output directory has none.
+func sharedCLI(t *testing.T) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "skill-porter")
+	os.WriteFile(script, []byte(`#!/bin/sh
+if [ "$1" = universal ]; then out="$4"; else out="$6"; fi
+mkdir -p "$out/shared"
+echo '{"name": "demo"}' > "$out/gemini-extension.json"
+[ -f "$out/shared/reference.md" ] || echo '# Generated reference' > "$out/shared/reference.md"
+echo '{"success": true, "sourcePlatform": "claude", "files": ["'"$out"'/gemini-extension.json"]}'
+`), 0755)
+	return script
+}
+
+func TestSubprocessConverter_KeepsExistingSharedFiles(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(src, "shared"), 0755)
+	os.WriteFile(filepath.Join(src, "shared", "reference.md"), []byte("# My edited reference\n"), 0644)
+
+	var c Converter = SubprocessConverter{Command: sharedCLI(t)}
+	planner, ok := c.(Planner)
+	if !ok {
+		t.Fatal("Expected the subprocess backend to stage conversions for review")
+	}
+	result, err := planner.Plan(context.Background(), domain.SkillDir{Path: src}, domain.TargetGemini, "")
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	var planned []string
+	for _, f := range result.Plan.Files() {
+		planned = append(planned, f.Path)
+	}
+	if strings.Join(planned, ",") != "gemini-extension.json" {
+		t.Errorf("Expected only the new manifest to be planned, got %v", planned)
+	}
+
+	if _, err := c.Convert(context.Background(), domain.SkillDir{Path: src}, domain.TargetGemini, ""); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(src, "shared", "reference.md"))
+	if string(data) != "# My edited reference\n" {
+		t.Errorf("Expected the edited reference to be kept, got %q", data)
+	}
+}
+
+func TestSubprocessConverter_UniversalCopiesSkill(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\n---\n"), 0644)
+	out := filepath.Join(t.TempDir(), "demo")
+
+	if _, err := (SubprocessConverter{Command: sharedCLI(t)}).Convert(context.Background(), domain.SkillDir{Path: src}, domain.TargetUniversal, out); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	for _, f := range []string{"SKILL.md", "gemini-extension.json"} {
+		if !fileExists(filepath.Join(out, f)) {
+			t.Errorf("Expected %s in the universal output", f)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package conversion
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"identical", "a\nb\n", "a\nb\n", ""},
+		{
+			"change in middle",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			"--- a/f.txt\n+++ b/f.txt\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			"append to empty",
+			"",
+			"x\n",
+			"--- a/f.txt\n+++ b/f.txt\n@@ -0,0 +1,1 @@\n+x\n",
+		},
+		{
+			"separate hunks",
+			"a\n1\n2\n3\n4\n5\n6\n7\nb\n",
+			"A\n1\n2\n3\n4\n5\n6\n7\nB\n",
+			"--- a/f.txt\n+++ b/f.txt\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("f.txt", []byte(tt.old), []byte(tt.new)); got != tt.want {
+				t.Errorf("Expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_LargeFiles(t *testing.T) {
+	var old, new strings.Builder
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&old, "old %d\n", i)
+		fmt.Fprintf(&new, "new %d\n", i)
+	}
+
+	// Only the differing middle is compared
+	got := UnifiedDiff("f.txt", []byte("head\nold 0\ntail\n"), []byte("head\nnew 0\ntail\n"))
+	want := "--- a/f.txt\n+++ b/f.txt\n@@ -1,3 +1,3 @@\n head\n-old 0\n+new 0\n tail\n"
+	if got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+
+	got = UnifiedDiff("f.txt", []byte(old.String()), []byte(new.String()))
+	want = "--- a/f.txt\n+++ b/f.txt\nfile too large to diff: 5000 lines → 5000 lines\n"
+	if got != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, got)
+	}
+	if ops := diffLines(splitLines(old.String()), splitLines(new.String())); len(ops) != 10000 {
+		t.Errorf("Expected every line replaced, got %d ops", len(ops))
+	}
+}
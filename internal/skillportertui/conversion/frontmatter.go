@@ -0,0 +1,325 @@
+package conversion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoFrontmatter is returned by ParseFrontmatter for Markdown without a
+// leading --- block.
+var ErrNoFrontmatter = errors.New("missing YAML frontmatter")
+
+const utf8BOM = "\ufeff"
+
+// Frontmatter is a Markdown file with a YAML header, such as SKILL.md or a
+// .claude/commands/*.md slash command. It is edited in place: Set and Delete
+// rewrite only the lines of the key they change, so comments, key order,
+// quoting and unknown keys elsewhere in the header are kept byte for byte.
+// A flow-style header such as {name: a, description: b} has no line per key,
+// so it is re-encoded as a whole instead. A UTF-8 BOM and CRLF line endings
+// are accepted and written back out.
+type Frontmatter struct {
+	bom     bool
+	newline string   // Line ending of the file, "\n" or "\r\n"
+	lines   []string // YAML between the --- lines, without line endings
+	closing string   // Line ending after the closing ---, empty at end of file
+	body    string   // Everything after the closing ---, as read
+	doc     *yaml.Node
+}
+
+// ParseFrontmatter splits data into its YAML header and Markdown body.
+func ParseFrontmatter(data []byte) (*Frontmatter, error) {
+	f, err := splitFrontmatter(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.parse(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// splitFrontmatter is ParseFrontmatter without parsing the YAML, so the body
+// of a file whose header is invalid can still be read.
+func splitFrontmatter(data []byte) (*Frontmatter, error) {
+	content := string(data)
+	f := &Frontmatter{newline: "\n"}
+	if strings.HasPrefix(content, utf8BOM) {
+		f.bom = true
+		content = strings.TrimPrefix(content, utf8BOM)
+	}
+
+	first, rest, ok := strings.Cut(content, "\n")
+	if !ok || strings.TrimRight(first, " \t\r") != "---" {
+		return nil, ErrNoFrontmatter
+	}
+	if strings.HasSuffix(first, "\r") {
+		f.newline = "\r\n"
+	}
+
+	for {
+		line, next, more := strings.Cut(rest, "\n")
+		if strings.TrimRight(line, " \t\r") == "---" {
+			if more {
+				f.closing = "\n"
+				if strings.HasSuffix(line, "\r") {
+					f.closing = "\r\n"
+				}
+			}
+			f.body = next
+			break
+		}
+		if !more {
+			return nil, fmt.Errorf("%w: no closing ---", ErrNoFrontmatter)
+		}
+		f.lines = append(f.lines, strings.TrimSuffix(line, "\r"))
+		rest = next
+	}
+	return f, nil
+}
+
+// NewFrontmatter returns an empty header for a new file.
+func NewFrontmatter() *Frontmatter {
+	return &Frontmatter{newline: "\n", closing: "\n", doc: &yaml.Node{Kind: yaml.MappingNode}}
+}
+
+// loadFrontmatter reads the Markdown file at path so it can be updated, or
+// starts a new one when there is none or it has no usable frontmatter.
+func loadFrontmatter(path string) *Frontmatter {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewFrontmatter()
+	}
+	f, err := ParseFrontmatter(data)
+	if err != nil {
+		return NewFrontmatter()
+	}
+	return f
+}
+
+// parse re-reads the YAML after the lines changed, so that line numbers of
+// the keys stay current.
+func (f *Frontmatter) parse() error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(f.lines, "\n")), &doc); err != nil {
+		return err
+	}
+	switch {
+	case len(doc.Content) == 0:
+		f.doc = &yaml.Node{Kind: yaml.MappingNode}
+	case doc.Content[0].Kind == yaml.MappingNode:
+		f.doc = doc.Content[0]
+	default:
+		return errors.New("frontmatter is not a mapping")
+	}
+	return nil
+}
+
+// Keys returns the top-level keys in file order.
+func (f *Frontmatter) Keys() []string {
+	var keys []string
+	for i := 0; i+1 < len(f.doc.Content); i += 2 {
+		keys = append(keys, f.doc.Content[i].Value)
+	}
+	return keys
+}
+
+// Has reports whether key is set.
+func (f *Frontmatter) Has(key string) bool {
+	return f.index(key) >= 0
+}
+
+// Get decodes the value of key into v and reports whether key is set.
+func (f *Frontmatter) Get(key string, v any) (bool, error) {
+	i := f.index(key)
+	if i < 0 {
+		return false, nil
+	}
+	return true, f.doc.Content[i+1].Decode(v)
+}
+
+// Fields returns the value nodes of the top-level keys. Node lines count
+// from the first line after the opening ---.
+func (f *Frontmatter) Fields() map[string]*yaml.Node {
+	fields := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(f.doc.Content); i += 2 {
+		fields[f.doc.Content[i].Value] = f.doc.Content[i+1]
+	}
+	return fields
+}
+
+// Decode decodes the whole header into v, like yaml.Unmarshal.
+func (f *Frontmatter) Decode(v any) error {
+	return f.doc.Decode(v)
+}
+
+// Entries returns every top-level key with its decoded value, in order.
+func (f *Frontmatter) Entries() ([]yamlEntry, error) {
+	var entries []yamlEntry
+	for i := 0; i+1 < len(f.doc.Content); i += 2 {
+		var value any
+		if err := f.doc.Content[i+1].Decode(&value); err != nil {
+			return nil, err
+		}
+		entries = append(entries, yamlEntry{Key: f.doc.Content[i].Value, Value: value})
+	}
+	return entries, nil
+}
+
+// Set gives key the value v. An existing key is rewritten where it stands,
+// and left alone entirely when it already holds v; a new key is appended.
+func (f *Frontmatter) Set(key string, v any) error {
+	if i := f.index(key); i >= 0 {
+		var current any
+		if err := f.doc.Content[i+1].Decode(&current); err == nil {
+			var want any
+			if err := roundtripYAML(v, &want); err == nil && reflect.DeepEqual(current, want) {
+				return nil
+			}
+		}
+	}
+
+	if f.doc.Style&yaml.FlowStyle != 0 {
+		var value yaml.Node
+		if err := value.Encode(v); err != nil {
+			return fmt.Errorf("encode %s: %w", key, err)
+		}
+		if i := f.index(key); i >= 0 {
+			f.doc.Content[i+1] = &value
+		} else {
+			f.doc.Content = append(f.doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &value)
+		}
+		return f.reencode()
+	}
+
+	text, err := marshalYAMLEntries([]yamlEntry{{Key: key, Value: v}})
+	if err != nil {
+		return err
+	}
+	replacement := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+
+	start, end, ok := f.span(key)
+	if !ok {
+		start, end = f.trimmedEnd(len(f.lines)), f.trimmedEnd(len(f.lines))
+	}
+	f.splice(start, end, replacement)
+	return f.parse()
+}
+
+// Delete removes key together with the comment lines directly above it.
+func (f *Frontmatter) Delete(key string) error {
+	if i := f.index(key); i >= 0 && f.doc.Style&yaml.FlowStyle != 0 {
+		f.doc.Content = append(f.doc.Content[:i], f.doc.Content[i+2:]...)
+		return f.reencode()
+	}
+	start, end, ok := f.span(key)
+	if !ok {
+		return nil
+	}
+	for start > 0 && strings.HasPrefix(f.lines[start-1], "#") {
+		start--
+	}
+	f.splice(start, end, nil)
+	return f.parse()
+}
+
+// Body returns the Markdown after the header with \n line endings.
+func (f *Frontmatter) Body() string {
+	return strings.ReplaceAll(f.body, "\r\n", "\n")
+}
+
+// SetBody replaces the Markdown after the header. body uses \n line endings;
+// they are converted to the file's own when written.
+func (f *Frontmatter) SetBody(body string) {
+	f.body = strings.ReplaceAll(body, "\n", f.newline)
+	if f.closing == "" {
+		f.closing = f.newline
+	}
+}
+
+// Bytes renders the file.
+func (f *Frontmatter) Bytes() []byte {
+	var b bytes.Buffer
+	if f.bom {
+		b.WriteString(utf8BOM)
+	}
+	b.WriteString("---" + f.newline)
+	for _, line := range f.lines {
+		b.WriteString(line + f.newline)
+	}
+	b.WriteString("---" + f.closing)
+	b.WriteString(f.body)
+	return b.Bytes()
+}
+
+// index returns the position of key's key node in the mapping, or -1.
+func (f *Frontmatter) index(key string) int {
+	for i := 0; i+1 < len(f.doc.Content); i += 2 {
+		if f.doc.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// span returns the lines [start, end) holding key and its value. Blank and
+// comment lines after the value are left out; they belong to the next key.
+func (f *Frontmatter) span(key string) (start, end int, ok bool) {
+	i := f.index(key)
+	if i < 0 {
+		return 0, 0, false
+	}
+	start = f.doc.Content[i].Line - 1
+	end = len(f.lines)
+	if i+2 < len(f.doc.Content) {
+		end = f.doc.Content[i+2].Line - 1
+	}
+	return start, max(f.trimmedEnd(end), start+1), true
+}
+
+// trimmedEnd moves end back over trailing blank and unindented comment lines.
+func (f *Frontmatter) trimmedEnd(end int) int {
+	for end > 0 {
+		line := f.lines[end-1]
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		end--
+	}
+	return end
+}
+
+// reencode replaces the header lines with the encoded mapping.
+func (f *Frontmatter) reencode() error {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(f.doc); err != nil {
+		return err
+	}
+	enc.Close()
+	f.lines = strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	return f.parse()
+}
+
+func (f *Frontmatter) splice(start, end int, replacement []string) {
+	lines := append([]string{}, f.lines[:start]...)
+	lines = append(lines, replacement...)
+	f.lines = append(lines, f.lines[end:]...)
+}
+
+// roundtripYAML converts v to the generic form yaml.v3 decodes values into,
+// so it can be compared with a decoded value.
+func roundtripYAML(v any, out *any) error {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return err
+	}
+	return node.Decode(out)
+}
@@ -0,0 +1,186 @@
+package conversion
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const frontmatterSample = `---
+# Skill identity
+name: demo   # kebab-case
+description: "Does things"
+
+# Tools the skill may use
+allowed-tools:
+  - Read
+  - Grep
+x-owner: platform-team
+---
+
+# Demo
+`
+
+func TestParseFrontmatter(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		body string
+	}{
+		{"LF", frontmatterSample, "\n# Demo\n"},
+		{"CRLF", strings.ReplaceAll(frontmatterSample, "\n", "\r\n"), "\n# Demo\n"},
+		{"BOM", utf8BOM + frontmatterSample, "\n# Demo\n"},
+		{"BOM and CRLF", utf8BOM + strings.ReplaceAll(frontmatterSample, "\n", "\r\n"), "\n# Demo\n"},
+		{"No body", "---\nname: demo\n---", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, err := ParseFrontmatter([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseFrontmatter failed: %v", err)
+			}
+			var name string
+			if ok, err := fm.Get("name", &name); !ok || err != nil || name != "demo" {
+				t.Errorf("Expected name demo, got %q (%v, %v)", name, ok, err)
+			}
+			if fm.Body() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, fm.Body())
+			}
+			if got := string(fm.Bytes()); got != tt.data {
+				t.Errorf("Unchanged frontmatter was not written back as read:\ngot  %q\nwant %q", got, tt.data)
+			}
+		})
+	}
+}
+
+func TestParseFrontmatter_Missing(t *testing.T) {
+	for _, data := range []string{"# No frontmatter\n", "---\nname: demo\n", ""} {
+		if _, err := ParseFrontmatter([]byte(data)); !errors.Is(err, ErrNoFrontmatter) {
+			t.Errorf("Expected ErrNoFrontmatter for %q, got %v", data, err)
+		}
+	}
+	if _, err := ParseFrontmatter([]byte("---\nname: [demo\n---\n")); err == nil || errors.Is(err, ErrNoFrontmatter) {
+		t.Errorf("Expected a YAML error for invalid frontmatter, got %v", err)
+	}
+}
+
+func TestFrontmatter_Set(t *testing.T) {
+	fm, _ := ParseFrontmatter([]byte(frontmatterSample))
+
+	// Setting a key to the value it has must not reformat it
+	fm.Set("name", "demo")
+	fm.Set("description", "Does things")
+	if string(fm.Bytes()) != frontmatterSample {
+		t.Errorf("Setting unchanged values changed the file:\n%s", fm.Bytes())
+	}
+
+	fm.Set("description", "Does other things")
+	fm.Set("allowed-tools", []string{"Read"})
+	fm.Set("model", "sonnet")
+	want := strings.Replace(frontmatterSample, `description: "Does things"`, "description: Does other things", 1)
+	want = strings.Replace(want, "  - Read\n  - Grep\n", "  - Read\n", 1)
+	want = strings.Replace(want, "x-owner: platform-team\n", "x-owner: platform-team\nmodel: sonnet\n", 1)
+	if got := string(fm.Bytes()); got != want {
+		t.Errorf("Unexpected file after Set:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if keys := fm.Keys(); strings.Join(keys, ",") != "name,description,allowed-tools,x-owner,model" {
+		t.Errorf("Unexpected key order %v", keys)
+	}
+}
+
+func TestFrontmatter_Delete(t *testing.T) {
+	fm, _ := ParseFrontmatter([]byte(strings.ReplaceAll(frontmatterSample, "\n", "\r\n")))
+
+	fm.Delete("allowed-tools")
+	fm.Delete("missing")
+	want := strings.Replace(frontmatterSample, "# Tools the skill may use\nallowed-tools:\n  - Read\n  - Grep\n", "", 1)
+	want = strings.ReplaceAll(want, "\n", "\r\n")
+	if got := string(fm.Bytes()); got != want {
+		t.Errorf("Unexpected file after Delete:\ngot  %q\nwant %q", got, want)
+	}
+	if fm.Has("allowed-tools") {
+		t.Error("Expected allowed-tools to be gone")
+	}
+}
+
+func TestFrontmatter_FlowStyle(t *testing.T) {
+	fm, err := ParseFrontmatter([]byte("---\n{name: a, description: b, x-owner: team}\n---\nBody\n"))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+
+	fm.Set("name", "demo")
+	fm.Set("model", "sonnet")
+	fm.Delete("x-owner")
+	want := "---\n{name: demo, description: b, model: sonnet}\n---\nBody\n"
+	if got := string(fm.Bytes()); got != want {
+		t.Errorf("Unexpected file:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFrontmatter_SetBody(t *testing.T) {
+	fm, _ := ParseFrontmatter([]byte(utf8BOM + "---\r\nname: demo\r\n---\r\nold\r\n"))
+	fm.SetBody("\nnew\nbody\n")
+	if got, want := string(fm.Bytes()), utf8BOM+"---\r\nname: demo\r\n---\r\n\r\nnew\r\nbody\r\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	fm = NewFrontmatter()
+	fm.Set("name", "demo")
+	fm.SetBody("\nBody\n")
+	if got, want := string(fm.Bytes()), "---\nname: demo\n---\n\nBody\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestClaudeToGemini_CRLFAndBOM converts a SKILL.md and command saved by an
+// editor on Windows.
+func TestClaudeToGemini_CRLFAndBOM(t *testing.T) {
+	src := t.TempDir()
+	crlf := func(s string) []byte { return []byte(utf8BOM + strings.ReplaceAll(s, "\n", "\r\n")) }
+	os.WriteFile(filepath.Join(src, "SKILL.md"), crlf("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "fix.md"), crlf("---\ndescription: Fix an issue\n---\nFix $ARGUMENTS\n"), 0644)
+
+	if _, err := NewClaudeToGeminiConverter(src, "").Convert(); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	manifest, _ := os.ReadFile(filepath.Join(src, "gemini-extension.json"))
+	if !strings.Contains(string(manifest), `"description": "Demo skill"`) {
+		t.Errorf("Description not taken from CRLF frontmatter:\n%s", manifest)
+	}
+	fix, _ := os.ReadFile(filepath.Join(src, "commands", "fix.toml"))
+	if want := "description = \"Fix an issue\"\n\nprompt = \"\"\"\nFix {{args}}\n\"\"\"\n"; string(fix) != want {
+		t.Errorf("Unexpected command TOML:\ngot  %q\nwant %q", fix, want)
+	}
+}
+
+// TestGeminiToClaude_UpdatesExistingSkill converts into a directory that
+// already has a SKILL.md: only the keys the converter owns may change.
+func TestGeminiToClaude_UpdatesExistingSkill(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"),
+		[]byte(`{"name": "demo", "version": "1.0.0", "description": "New description", "excludeTools": ["Bash"]}`), 0644)
+	os.WriteFile(filepath.Join(src, "GEMINI.md"), []byte("# Demo\n\nInstructions\n"), 0644)
+
+	out := t.TempDir()
+	existing := "---\r\n# Maintained by hand\r\nname: demo\r\ndescription: Old description\r\nlicense: MIT # keep\r\n---\r\n\r\nOld body\r\n"
+	os.WriteFile(filepath.Join(out, "SKILL.md"), []byte(existing), 0644)
+
+	if _, err := NewGeminiToClaudeConverter(src, out).Convert(); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "SKILL.md"))
+	got := string(data)
+	if !strings.HasPrefix(got, "---\r\n# Maintained by hand\r\nname: demo\r\ndescription: New description\r\nlicense: MIT # keep\r\nallowed-tools:\r\n") {
+		t.Errorf("Existing frontmatter not updated in place:\n%q", got)
+	}
+	if strings.Contains(got, "Old body") || !strings.Contains(got, "Instructions\r\n") {
+		t.Errorf("Expected the body to be regenerated:\n%q", got)
+	}
+}
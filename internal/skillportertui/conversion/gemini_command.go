@@ -0,0 +1,210 @@
+package conversion
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// GeminiCommand is a Gemini CLI custom command, commands/<name>.toml.
+type GeminiCommand struct {
+	Name        string // Relative to commands/, without .toml; "git/commit" for a namespaced command
+	Description string
+	Prompt      string
+	// Comment is written as # lines above the prompt.
+	Comment string
+	// Extra holds every top-level key other than description and prompt, so
+	// that keys this converter does not understand survive a conversion.
+	Extra map[string]any
+
+	keys []string // Order of the keys in Extra as they appeared in the file
+}
+
+// ParseGeminiCommand parses the TOML of the command name.
+func ParseGeminiCommand(name string, data []byte) (*GeminiCommand, error) {
+	var values map[string]any
+	md, err := toml.Decode(string(data), &values)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &GeminiCommand{Name: name}
+	for key, dest := range map[string]*string{"description": &cmd.Description, "prompt": &cmd.Prompt} {
+		v, ok := values[key]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string, got %s", key, md.Type(key))
+		}
+		*dest = s
+		delete(values, key)
+	}
+
+	if len(values) > 0 {
+		cmd.Extra = values
+		for _, key := range md.Keys() {
+			if len(key) == 1 {
+				if _, ok := values[key[0]]; ok {
+					cmd.keys = append(cmd.keys, key[0])
+				}
+			}
+		}
+	}
+	return cmd, nil
+}
+
+// SetExtra adds a key to be written after the prompt.
+func (c *GeminiCommand) SetExtra(key string, value any) {
+	if c.Extra == nil {
+		c.Extra = map[string]any{}
+	}
+	if _, ok := c.Extra[key]; !ok {
+		c.keys = append(c.keys, key)
+	}
+	c.Extra[key] = value
+}
+
+// UnknownKeys returns the keys in Extra in file order.
+func (c *GeminiCommand) UnknownKeys() []string {
+	keys := make([]string, 0, len(c.Extra))
+	seen := map[string]bool{}
+	for _, k := range c.keys {
+		if _, ok := c.Extra[k]; ok && !seen[k] {
+			keys = append(keys, k)
+			seen[k] = true
+		}
+	}
+	// Keys added to Extra directly have no recorded position
+	var rest []string
+	for k := range c.Extra {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// MarshalTOML renders the command. The prompt is written as a multi-line
+// string so it stays readable; unknown keys follow it, tables last.
+func (c *GeminiCommand) MarshalTOML() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "description = %s\n\n", tomlString(c.Description))
+	if c.Comment != "" {
+		for _, line := range strings.Split(c.Comment, "\n") {
+			fmt.Fprintf(&b, "# %s\n", line)
+		}
+	}
+	fmt.Fprintf(&b, "prompt = %s\n", tomlMultiline(c.Prompt))
+
+	keys := c.UnknownKeys()
+	if len(keys) == 0 {
+		return b.Bytes(), nil
+	}
+
+	// Tables must follow plain keys, or their keys would be read as part of
+	// the table
+	var plain, tables []string
+	for _, k := range keys {
+		switch c.Extra[k].(type) {
+		case map[string]any, []map[string]any:
+			tables = append(tables, k)
+		default:
+			plain = append(plain, k)
+		}
+	}
+	for i, k := range append(plain, tables...) {
+		if i == 0 || i >= len(plain) {
+			b.WriteString("\n")
+		}
+		enc := toml.NewEncoder(&b)
+		enc.Indent = ""
+		if err := enc.Encode(map[string]any{k: c.Extra[k]}); err != nil {
+			return nil, fmt.Errorf("encode %s: %w", k, err)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlMultiline renders s as a multi-line string with the quotes on lines of
+// their own, adding a final newline to s if it has none. Text is written
+// verbatim where TOML allows it: as a basic string when it has no
+// backslashes or triple quotes, otherwise as a literal string. Only text that
+// fits neither is escaped.
+func tomlMultiline(s string) string {
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	verbatim := !strings.ContainsFunc(s, func(r rune) bool {
+		return (r < 0x20 && r != '\t' && r != '\n') || r == 0x7f
+	})
+	switch {
+	case verbatim && !strings.Contains(s, `\`) && !strings.Contains(s, `"""`):
+		return "\"\"\"\n" + s + "\"\"\""
+	case verbatim && !strings.Contains(s, "'''"):
+		return "'''\n" + s + "'''"
+	}
+
+	var b strings.Builder
+	b.WriteString("\"\"\"\n")
+	quotes := 0
+	for _, r := range s {
+		if r == '"' {
+			// Break up runs of quotes so they cannot close the string
+			if quotes == 2 {
+				b.WriteString(`\"`)
+				quotes = 0
+				continue
+			}
+			quotes++
+			b.WriteRune(r)
+			continue
+		}
+		quotes = 0
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString(`"""`)
+	return b.String()
+}
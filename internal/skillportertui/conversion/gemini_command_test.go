@@ -0,0 +1,123 @@
+package conversion
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// TestGeminiCommand_Golden parses every command in testdata/commands, writes
+// it back out and compares the result with its .golden file. The output must
+// parse back to the same command.
+func TestGeminiCommand_Golden(t *testing.T) {
+	inputs, _ := filepath.Glob(filepath.Join("testdata", "commands", "*.toml"))
+	if len(inputs) == 0 {
+		t.Fatal("no golden inputs found")
+	}
+
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".toml")
+		t.Run(name, func(t *testing.T) {
+			data, _ := os.ReadFile(input)
+			cmd, err := ParseGeminiCommand(name, data)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			got, err := cmd.MarshalTOML()
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			golden := input + ".golden"
+			if *update {
+				os.WriteFile(golden, got, 0644)
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing golden file, run with -update: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Output differs from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+
+			back, err := ParseGeminiCommand(name, got)
+			if err != nil {
+				t.Fatalf("output is not valid TOML: %v\n%s", err, got)
+			}
+			if back.Description != cmd.Description || strings.TrimSuffix(back.Prompt, "\n") != strings.TrimSuffix(cmd.Prompt, "\n") {
+				t.Errorf("Round trip changed the command:\ngot  %q / %q\nwant %q / %q", back.Description, back.Prompt, cmd.Description, cmd.Prompt)
+			}
+			if !reflect.DeepEqual(back.Extra, cmd.Extra) {
+				t.Errorf("Round trip changed the extra keys:\ngot  %v\nwant %v", back.Extra, cmd.Extra)
+			}
+		})
+	}
+}
+
+func TestGeminiCommand_UnknownKeys(t *testing.T) {
+	data, _ := os.ReadFile(filepath.Join("testdata", "commands", "extra-keys.toml"))
+	cmd, err := ParseGeminiCommand("extra-keys", data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []string{"category", "tags", "timeout", "metadata"}
+	if got := cmd.UnknownKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected unknown keys %v in file order, got %v", want, got)
+	}
+}
+
+func TestGeminiCommand_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		toml string
+	}{
+		{"unterminated string", `description = "oops`},
+		{"description not a string", "description = 42\n"},
+		{"prompt not a string", "prompt = [\"a\"]\n"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseGeminiCommand("bad", []byte(tt.toml)); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+// TestMarshalTOML_Escaping checks that any description and prompt survive
+// being written and read back.
+func TestMarshalTOML_Escaping(t *testing.T) {
+	tests := []struct {
+		description string
+		prompt      string
+	}{
+		{`Fix the "parser"`, "plain"},
+		{`C:\path\to`, `match \d+ and \n`},
+		{"tab\tand\u0001control", "bell\u0007 and \"\"\" quotes"},
+		{`ends with \`, `ends with quotes ""`},
+		{"line\nbreak", "both ''' and \"\"\" and \\"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		data, err := (&GeminiCommand{Description: tt.description, Prompt: tt.prompt}).MarshalTOML()
+		if err != nil {
+			t.Fatalf("marshal %q: %v", tt.description, err)
+		}
+		var got struct {
+			Description string
+			Prompt      string
+		}
+		if _, err := toml.Decode(string(data), &got); err != nil {
+			t.Errorf("%q: invalid TOML: %v\n%s", tt.description, err, data)
+			continue
+		}
+		if got.Description != tt.description || got.Prompt != tt.prompt+"\n" {
+			t.Errorf("Round trip mismatch:\ngot  %q / %q\nwant %q / %q", got.Description, got.Prompt, tt.description, tt.prompt+"\n")
+		}
+	}
+}
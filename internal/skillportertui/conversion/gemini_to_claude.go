@@ -0,0 +1,490 @@
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/mcp"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+)
+
+var (
+	geminiHeaderRe     = regexp.MustCompile(`(?m)^#\s+.+?\s+-\s+Gemini CLI Extension\n\n`)
+	geminiQuickStartRe = regexp.MustCompile(`(?m)##\s+Quick Start[\s\S]+?After installation.+?\n\n`)
+	geminiFooterRe     = regexp.MustCompile(`(?s)\n---\n\n\*This extension was converted.+?\*\n$`)
+	personaRe          = regexp.MustCompile(`(?i)You are a|Act as|Your role is`)
+)
+
+var keywordStopWords = []string{"the", "a", "an", "and", "or", "but", "for", "with", "to", "from", "in", "on"}
+
+// geminiManifest is the subset of gemini-extension.json used by the converter.
+type geminiManifest struct {
+	Name            string          `json:"name"`
+	Version         any             `json:"version"`
+	Description     string          `json:"description"`
+	ContextFileName string          `json:"contextFileName"`
+	ExcludeTools    []string        `json:"excludeTools"`
+	ClaudeExclude   []string        `json:"claudeExcludeTools"` // See claudeExcludeToolsKey
+	Settings        []Setting       `json:"settings"`
+	MCPServers      *jsonobj.Object `json:"mcpServers"`
+}
+
+type migrationInsight struct {
+	Type    string
+	Command string
+	Message string
+}
+
+// GeminiToClaudeConverter converts a Gemini CLI extension into a Claude Code
+// skill. It is a port of GeminiToClaudeConverter in
+// src/converters/gemini-to-claude.js and produces the same files.
+type GeminiToClaudeConverter struct {
+	SourcePath string
+	OutputPath string
+	Options    Options
+
+	manifest    geminiManifest
+	contextFile string // Relative to the extension
+	content     string
+	commands    []*GeminiCommand
+	agents      []*GeminiCommand // Converted subagents, see isClaudeSubagent
+	warnings    []string
+	rewrites    []domain.MCPRewrite
+	lossy       []domain.LossyField
+	previews    []domain.CommandPreview
+	assets      []string // Files under the extension that the context file references
+	missing     []string
+	plan        *Plan
+}
+
+// NewGeminiToClaudeConverter creates a converter. An empty outputPath converts in place.
+func NewGeminiToClaudeConverter(sourcePath, outputPath string) *GeminiToClaudeConverter {
+	if outputPath == "" {
+		outputPath = sourcePath
+	}
+	return &GeminiToClaudeConverter{SourcePath: sourcePath, OutputPath: outputPath}
+}
+
+// Convert performs the conversion and returns the generated files.
+func (c *GeminiToClaudeConverter) Convert() (*Result, error) {
+	result, err := c.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Plan.Apply(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Plan builds the conversion without writing anything to disk.
+func (c *GeminiToClaudeConverter) Plan() (*Result, error) {
+	return c.planInto(NewPlan(c.OutputPath))
+}
+
+func (c *GeminiToClaudeConverter) planInto(plan *Plan) (*Result, error) {
+	c.plan = plan
+	result := &Result{Source: domain.PlatformGemini, Plan: plan}
+
+	if err := c.extractGeminiMetadata(); err != nil {
+		return nil, err
+	}
+
+	skillPath, err := c.generateClaudeSkill()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, skillPath)
+
+	marketplacePath, err := c.generateMarketplaceJSON()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, marketplacePath)
+
+	commandFiles, err := c.generateClaudeCommands()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, commandFiles...)
+
+	agentFiles, err := c.generateClaudeAgents()
+	if err != nil {
+		return nil, err
+	}
+	result.Files = append(result.Files, agentFiles...)
+
+	assetFiles, err := copyAssets(c.plan, c.SourcePath, c.assets)
+	if err != nil {
+		return nil, fmt.Errorf("copy assets: %w", err)
+	}
+	result.Files = append(result.Files, assetFiles...)
+
+	ensureSharedStructure(c.plan)
+	c.generateMigrationInsights()
+
+	result.Warnings = c.warnings
+	result.MCPRewrites = c.rewrites
+	result.Lossy = c.lossy
+	result.Commands = c.previews
+	result.MissingFiles = c.missing
+	return result, nil
+}
+
+func (c *GeminiToClaudeConverter) extractGeminiMetadata() error {
+	data, err := os.ReadFile(filepath.Join(c.SourcePath, "gemini-extension.json"))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &c.manifest); err != nil {
+		return fmt.Errorf("invalid gemini-extension.json: %w", err)
+	}
+
+	c.contextFile = c.manifest.ContextFileName
+	if c.contextFile == "" {
+		c.contextFile = "GEMINI.md"
+	}
+	// The context file is optional
+	if content, err := os.ReadFile(filepath.Join(c.SourcePath, c.contextFile)); err == nil {
+		c.content = string(content)
+	}
+
+	// Converted subagents are told apart from commands by their marker
+	commandsDir := filepath.Join(c.SourcePath, "commands")
+	names, err := walkCommands(commandsDir, ".toml")
+	if err != nil {
+		return fmt.Errorf("read commands: %w", err)
+	}
+	for _, name := range names {
+		cmdContent, err := os.ReadFile(filepath.Join(commandsDir, filepath.FromSlash(name)+".toml"))
+		if err != nil {
+			return err
+		}
+		cmd, err := ParseGeminiCommand(name, cmdContent)
+		if err != nil {
+			return fmt.Errorf("invalid commands/%s.toml: %w", name, err)
+		}
+		if isClaudeSubagent(cmd) {
+			cmd.Name = strings.TrimPrefix(cmd.Name, geminiAgentsDir+"/")
+			c.agents = append(c.agents, cmd)
+			continue
+		}
+		c.commands = append(c.commands, cmd)
+	}
+
+	return nil
+}
+
+func (c *GeminiToClaudeConverter) generateClaudeSkill() (string, error) {
+	m := c.manifest
+
+	// Update an existing SKILL.md in the output rather than replacing its
+	// frontmatter, so keys and comments added by hand survive
+	fm := loadFrontmatter(c.plan.Abs("SKILL.md"))
+	if err := fm.Set("name", m.Name); err != nil {
+		return "", err
+	}
+	if err := fm.Set("description", m.Description); err != nil {
+		return "", err
+	}
+	var err error
+	if len(m.ExcludeTools) > 0 || len(m.ClaudeExclude) > 0 {
+		err = fm.Set("allowed-tools", c.convertExcludeToAllowedTools(m.ExcludeTools))
+	} else {
+		err = fm.Delete("allowed-tools")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "# %s - Claude Code Skill\n\n", m.Name)
+	fmt.Fprintf(&b, "%s\n\n", m.Description)
+
+	// Strip the Gemini-specific header, quick start and conversion footer
+	cleanContent := replaceFirst(geminiHeaderRe, c.content, "")
+	cleanContent = replaceFirst(geminiQuickStartRe, cleanContent, "")
+	cleanContent = geminiFooterRe.ReplaceAllString(cleanContent, "")
+
+	// Context file links are rebased onto SKILL.md
+	cleanContent, c.assets, c.missing = rebaseLinks(cleanContent, c.SourcePath, path.Clean(filepath.ToSlash(c.contextFile)), c.OutputPath, "SKILL.md")
+	for i, m := range c.missing {
+		c.missing[i] = c.contextFile + ": " + m
+	}
+
+	if len(m.Settings) > 0 {
+		b.WriteString("## Configuration\n\nThis skill requires the following environment variables:\n\n")
+		for _, setting := range m.Settings {
+			fmt.Fprintf(&b, "- `%s`: %s", setting.Name, setting.Description)
+			if isTruthy(setting.Default) {
+				fmt.Fprintf(&b, " (default: %v)", setting.Default)
+			}
+			if setting.Required {
+				b.WriteString(" **(required)**")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\nSet these in your environment or Claude Code configuration.\n\n")
+	}
+
+	if trimmed := strings.TrimSpace(cleanContent); trimmed != "" {
+		b.WriteString(trimmed + "\n\n")
+	} else {
+		fmt.Fprintf(&b, "## Usage\n\nUse this skill when you need %s.\n\n", strings.ToLower(m.Description))
+	}
+
+	b.WriteString("---\n\n")
+	fmt.Fprintf(&b, "*This skill was converted from a Gemini CLI extension using [skill-porter](%s)*\n", converterRepoURL)
+
+	fm.SetBody(b.String())
+	return c.plan.Add("SKILL.md", fm.Bytes(), 0644), nil
+}
+
+// convertExcludeToAllowedTools turns Gemini's excludeTools blacklist into
+// Claude's allowed-tools whitelist, including the MCP tools the extension's
+// servers list in includeTools. Claude-only tools listed under
+// claudeExcludeToolsKey stay excluded.
+func (c *GeminiToClaudeConverter) convertExcludeToAllowedTools(excludeTools []string) []string {
+	catalog := c.Options.catalog()
+	excluded := map[string]bool{}
+	for _, name := range c.manifest.ClaudeExclude {
+		if tool, ok := catalog.Lookup(domain.PlatformClaude, name); ok {
+			excluded[toolKey(tool)] = true
+		}
+	}
+	for _, name := range excludeTools {
+		if tools.IsMCP(name) {
+			c.warnings = append(c.warnings, fmt.Sprintf("excludeTools: MCP tool %q cannot be excluded in allowed-tools and was ignored", name))
+			continue
+		}
+		tool, ok := catalog.Lookup(domain.PlatformGemini, name)
+		if !ok {
+			c.warnings = append(c.warnings, fmt.Sprintf("excludeTools: unknown tool %q is not in the tool catalog and was ignored", name))
+			continue
+		}
+		excluded[toolKey(tool)] = true
+	}
+
+	allowed := []string{}
+	for _, tool := range catalog.Tools {
+		if tool.Claude != "" && !excluded[toolKey(tool)] {
+			allowed = append(allowed, tool.Claude)
+		}
+	}
+
+	// MCP tools are only listed when the server names them in includeTools;
+	// allowing a whole server would pre-approve more than the extension did
+	servers := c.manifest.MCPServers
+	for _, server := range servers.Keys() {
+		config := servers.Object(server)
+		includeValue, _ := config.Get("includeTools")
+		excludeValue, _ := config.Get("excludeTools")
+		include, exclude := stringList(includeValue), stringList(excludeValue)
+		if len(include) == 0 {
+			c.warnings = append(c.warnings, fmt.Sprintf("MCP server %q has no includeTools; add %s to allowed-tools to pre-approve its tools", server, tools.MCPName(server, "")))
+			continue
+		}
+		for _, tool := range include {
+			if !containsString(exclude, tool) {
+				allowed = append(allowed, tools.MCPName(server, tool))
+			}
+		}
+	}
+	return allowed
+}
+
+func (c *GeminiToClaudeConverter) generateMarketplaceJSON() (string, error) {
+	m := c.manifest
+
+	var version any = "1.0.0"
+	if isTruthy(m.Version) {
+		version = m.Version
+	}
+
+	owner := jsonobj.New()
+	owner.Set("name", "Skill Porter User")
+	owner.Set("email", "user@example.com")
+
+	metadata := jsonobj.New()
+	metadata.Set("description", m.Description)
+	metadata.Set("version", version)
+
+	repository := jsonobj.New()
+	repository.Set("type", "git")
+	repository.Set("url", "https://github.com/user/"+m.Name)
+
+	plugin := jsonobj.New()
+	plugin.Set("name", m.Name)
+	plugin.Set("description", m.Description)
+	plugin.Set("source", ".")
+	plugin.Set("strict", false)
+	plugin.Set("author", "Converted from Gemini")
+	plugin.Set("repository", repository)
+	plugin.Set("license", "MIT")
+	plugin.Set("keywords", extractKeywords(m.Description))
+	plugin.Set("category", "general")
+	plugin.Set("tags", []string{})
+	plugin.Set("skills", []string{"."})
+	if m.MCPServers != nil {
+		servers, rewrites := mcp.ToClaude(m.MCPServers, mcp.RelativePaths)
+		plugin.Set("mcpServers", servers)
+		c.rewrites = append(c.rewrites, rewrites...)
+		for _, r := range rewrites {
+			if r.Lossy {
+				c.warnings = append(c.warnings, "mcpServers."+r.String())
+			}
+		}
+	}
+
+	marketplace := jsonobj.New()
+	marketplace.Set("name", m.Name+"-marketplace")
+	marketplace.Set("owner", owner)
+	marketplace.Set("metadata", metadata)
+	marketplace.Set("plugins", []any{plugin})
+
+	data, err := jsonobj.MarshalFile(marketplace)
+	if err != nil {
+		return "", err
+	}
+	return c.plan.Add(".claude-plugin/marketplace.json", data, 0644), nil
+}
+
+func (c *GeminiToClaudeConverter) generateClaudeCommands() ([]string, error) {
+	var files []string
+	if len(c.commands) == 0 {
+		return files, nil
+	}
+
+	// Claude Code runs .claude/commands/git/commit.md as /commit, so commands
+	// that only differ by namespace end up with the same name
+	names := make([]string, len(c.commands))
+	for i, cmd := range c.commands {
+		names[i] = cmd.Name
+	}
+	collisions := claudeCommandCollisions(names)
+	for _, base := range sortedKeys(collisions) {
+		var invocations []string
+		for _, name := range collisions[base] {
+			invocations = append(invocations, commandInvocation(name))
+		}
+		c.warnings = append(c.warnings, fmt.Sprintf("%s all run as /%s in Claude Code, which ignores command folders; rename all but one",
+			strings.Join(invocations, ", "), base))
+	}
+
+	for _, cmd := range c.commands {
+		description := cmd.Description
+		if description == "" {
+			description = "Run " + cmd.Name
+		}
+
+		// Unknown keys go into the frontmatter so converting back restores them
+		rel := ".claude/commands/" + cmd.Name + ".md"
+		fm := loadFrontmatter(c.plan.Abs(rel))
+		if err := fm.Set("description", description); err != nil {
+			return nil, fmt.Errorf("commands/%s.toml: %w", cmd.Name, err)
+		}
+		var unknown []string
+		for _, k := range cmd.UnknownKeys() {
+			if err := fm.Set(k, cmd.Extra[k]); err != nil {
+				return nil, fmt.Errorf("commands/%s.toml: %w", cmd.Name, err)
+			}
+			// Claude's own keys, such as argument-hint, are expected here
+			if !containsString(claudeCommandKeys, k) {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) > 0 {
+			c.warnings = append(c.warnings, fmt.Sprintf("commands/%s.toml: kept unknown key(s) %s in .claude/commands/%s.md frontmatter",
+				cmd.Name, strings.Join(unknown, ", "), cmd.Name))
+		}
+
+		// Gemini: {{args}} -> Claude: $ARGUMENTS, and back to $1, $2, ...
+		// where the prompt names positional arguments; !{cmd} -> !`cmd`;
+		// @{path} -> @path
+		prompt, rewrites := translateGeminiPrompt(strings.TrimSpace(cmd.Prompt))
+		prompt = strings.TrimSpace(prompt)
+		preview := domain.CommandPreview{Source: "commands/" + cmd.Name + ".toml", Target: rel, Prompt: prompt}
+		for _, r := range rewrites {
+			if r.Lossy {
+				preview.Notes = append(preview.Notes, r.String())
+				c.warnings = append(c.warnings, fmt.Sprintf("%s: %s", rel, r))
+			}
+		}
+		c.previews = append(c.previews, preview)
+
+		fm.SetBody("\n" + prompt + "\n")
+
+		files = append(files, c.plan.Add(rel, fm.Bytes(), 0644))
+	}
+
+	return files, nil
+}
+
+// generateMigrationInsights writes shared/MIGRATION_INSIGHTS.md with
+// suggestions for commands that would work better as skill instructions.
+func (c *GeminiToClaudeConverter) generateMigrationInsights() {
+	var insights []migrationInsight
+	for _, cmd := range c.commands {
+		if personaRe.MatchString(cmd.Prompt) {
+			insights = append(insights, migrationInsight{
+				Type:    "PERSONA_DETECTED",
+				Command: cmd.Name,
+				Message: fmt.Sprintf("Command `/%s` appears to define a persona. Consider moving this logic to `SKILL.md` instructions so Claude can adopt it automatically without a slash command.", cmd.Name),
+			})
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Migration Insights & Recommendations\n\n")
+	b.WriteString("Generated during conversion from Gemini to Claude.\n\n")
+
+	if len(insights) > 0 {
+		b.WriteString("## 💡 Optimization Opportunities\n\n")
+		b.WriteString("While we successfully converted your commands to Claude Slash Commands, some might work better as native Skill instructions.\n\n")
+		for _, insight := range insights {
+			fmt.Fprintf(&b, "### `/%s`\n", insight.Command)
+			fmt.Fprintf(&b, "%s\n\n", insight.Message)
+		}
+		b.WriteString("## How to Apply\n")
+		b.WriteString("1. Open `SKILL.md`\n")
+		b.WriteString("2. Paste the prompt instructions into the main description area.\n")
+		fmt.Fprintf(&b, "3. Delete `.claude/commands/%s.md` if you prefer automatic invocation.\n", insights[0].Command)
+	} else {
+		b.WriteString("✅ No specific architectural changes recommended. The direct conversion should work well.\n")
+	}
+
+	c.plan.Add("shared/MIGRATION_INSIGHTS.md", []byte(b.String()), 0644)
+}
+
+// extractKeywords picks up to five significant words from a description.
+func extractKeywords(description string) []string {
+	keywords := []string{}
+	for _, word := range strings.Fields(strings.ToLower(description)) {
+		if utf8.RuneCountInString(word) > 3 && !containsString(keywordStopWords, word) {
+			keywords = append(keywords, word)
+			if len(keywords) == 5 {
+				break
+			}
+		}
+	}
+	return keywords
+}
+
+// replaceFirst replaces only the first match of re, like String.replace
+// with a non-global regex.
+func replaceFirst(re *regexp.Regexp, s, repl string) string {
+	loc := re.FindStringIndex(s)
+	if loc == nil {
+		return s
+	}
+	return s[:loc[0]] + repl + s[loc[1]:]
+}
@@ -0,0 +1,166 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeminiToClaude_MatchesFixture(t *testing.T) {
+	fixture := filepath.Join(fixturesDir, "api-connector-converted")
+	src := t.TempDir()
+	copyFixture(t, filepath.Join(fixturesDir, "..", "api-connector-gemini", "gemini-extension.json"), filepath.Join(src, "gemini-extension.json"))
+	copyFixture(t, filepath.Join(fixturesDir, "..", "api-connector-gemini", "GEMINI.md"), filepath.Join(src, "GEMINI.md"))
+
+	out := filepath.Join(t.TempDir(), "api-connector")
+	result, err := NewGeminiToClaudeConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	if len(result.Files) != 2 {
+		t.Errorf("Expected 2 generated files, got %v", result.Files)
+	}
+
+	assertSameFile(t, filepath.Join(fixture, "SKILL.md"), filepath.Join(out, "SKILL.md"))
+	assertSameFile(t, filepath.Join(fixture, ".claude-plugin", "marketplace.json"), filepath.Join(out, ".claude-plugin", "marketplace.json"))
+
+	insights, err := os.ReadFile(filepath.Join(out, "shared", "MIGRATION_INSIGHTS.md"))
+	if err != nil {
+		t.Fatalf("MIGRATION_INSIGHTS.md not written: %v", err)
+	}
+	if !strings.Contains(string(insights), "No specific architectural changes recommended") {
+		t.Errorf("Unexpected insights:\n%s", insights)
+	}
+}
+
+func TestGeminiToClaude_Commands(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"),
+		[]byte(`{"name": "helper", "version": "1.0.0", "description": "Helps out"}`), 0644)
+	os.MkdirAll(filepath.Join(src, "commands"), 0755)
+	os.WriteFile(filepath.Join(src, "commands", "review.toml"),
+		[]byte("description = \"Review code\"\n\nprompt = \"\"\"\nYou are a strict reviewer. Review {{args}}\n\"\"\"\n"), 0644)
+
+	result, err := NewGeminiToClaudeConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(result.Files) != 3 {
+		t.Errorf("Expected 3 generated files, got %v", result.Files)
+	}
+
+	cmd, err := os.ReadFile(filepath.Join(src, ".claude", "commands", "review.md"))
+	if err != nil {
+		t.Fatalf("command not written: %v", err)
+	}
+	want := "---\ndescription: Review code\n---\n\nYou are a strict reviewer. Review $ARGUMENTS\n"
+	if string(cmd) != want {
+		t.Errorf("Unexpected command markdown:\ngot  %q\nwant %q", cmd, want)
+	}
+
+	insights, _ := os.ReadFile(filepath.Join(src, "shared", "MIGRATION_INSIGHTS.md"))
+	if !strings.Contains(string(insights), "### `/review`") {
+		t.Errorf("Expected persona insight for /review, got:\n%s", insights)
+	}
+
+	skill, _ := os.ReadFile(filepath.Join(src, "SKILL.md"))
+	if !strings.Contains(string(skill), "## Usage\n\nUse this skill when you need helps out.") {
+		t.Errorf("Expected generated usage section, got:\n%s", skill)
+	}
+}
+
+func TestGeminiToClaude_CommandUnknownKeys(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"),
+		[]byte(`{"name": "helper", "version": "1.0.0", "description": "Helps out"}`), 0644)
+	os.MkdirAll(filepath.Join(src, "commands"), 0755)
+	copyFixture(t, filepath.Join("testdata", "commands", "extra-keys.toml"), filepath.Join(src, "commands", "lint.toml"))
+
+	result, err := NewGeminiToClaudeConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "category, tags, timeout, metadata") {
+		t.Errorf("Expected the unknown keys to be reported, got %v", result.Warnings)
+	}
+
+	cmd, _ := os.ReadFile(filepath.Join(src, ".claude", "commands", "lint.md"))
+	want := "---\ndescription: Lint files\ncategory: quality\ntags:\n  - lint\n  - ci\ntimeout: 30\nmetadata:\n  owner: platform\n---\n\nLint $ARGUMENTS.\n"
+	if string(cmd) != want {
+		t.Errorf("Unexpected command markdown:\ngot  %q\nwant %q", cmd, want)
+	}
+
+	// Converting back restores the keys
+	os.Remove(filepath.Join(src, "gemini-extension.json"))
+	os.RemoveAll(filepath.Join(src, "commands"))
+	if _, err := NewClaudeToGeminiConverter(src, "").Convert(); err != nil {
+		t.Fatalf("Convert back failed: %v", err)
+	}
+	back, _ := os.ReadFile(filepath.Join(src, "commands", "lint.toml"))
+	golden, _ := os.ReadFile(filepath.Join("testdata", "commands", "extra-keys.toml.golden"))
+	if string(back) != string(golden) {
+		t.Errorf("Expected the round trip to match the golden file:\ngot:\n%s\nwant:\n%s", back, golden)
+	}
+}
+
+func TestGeminiToClaude_InvalidCommand(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"),
+		[]byte(`{"name": "helper", "version": "1.0.0", "description": "Helps out"}`), 0644)
+	os.MkdirAll(filepath.Join(src, "commands"), 0755)
+	os.WriteFile(filepath.Join(src, "commands", "bad.toml"), []byte("description = \"unterminated\n"), 0644)
+
+	_, err := NewGeminiToClaudeConverter(src, "").Convert()
+	if err == nil || !strings.Contains(err.Error(), "commands/bad.toml") {
+		t.Errorf("Expected an error naming the bad command, got %v", err)
+	}
+}
+
+func TestGeminiToClaude_Tools(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"), []byte(`{
+  "name": "demo",
+  "version": "1.0.0",
+  "description": "Demo extension",
+  "mcpServers": {
+    "db": {"command": "db-mcp", "includeTools": ["query", "schema", "drop"], "excludeTools": ["drop"]},
+    "cache": {"command": "cache-mcp"}
+  },
+  "excludeTools": ["run_shell_command", "Edit", "teleport"]
+}`), 0644)
+	os.WriteFile(filepath.Join(src, "GEMINI.md"), []byte("# Demo\n"), 0644)
+
+	out := t.TempDir()
+	result, err := NewGeminiToClaudeConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "SKILL.md"))
+	fm, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("Invalid SKILL.md: %v", err)
+	}
+	var allowed []string
+	fm.Get("allowed-tools", &allowed)
+
+	for _, tool := range []string{"Read", "Grep", "Task", "mcp__db__query", "mcp__db__schema"} {
+		if !containsString(allowed, tool) {
+			t.Errorf("Expected %s to be allowed, got %v", tool, allowed)
+		}
+	}
+	for _, tool := range []string{"Bash", "Edit", "mcp__db__drop", "mcp__cache"} {
+		if containsString(allowed, tool) {
+			t.Errorf("Expected %s not to be allowed, got %v", tool, allowed)
+		}
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, want := range []string{`unknown tool "teleport"`, `MCP server "cache" has no includeTools`} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected a warning containing %q, got %v", want, result.Warnings)
+		}
+	}
+}
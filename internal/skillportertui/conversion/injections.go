@@ -0,0 +1,160 @@
+package conversion
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Both platforms let a command prompt pull in shell output and files:
+//
+//	Claude            Gemini
+//	!`git status`     !{git status}
+//	@src/app.ts       @{src/app.ts}
+//
+// Arguments inside a shell command differ: Gemini shell-escapes {{args}} and
+// passes it as a single word, Claude substitutes $ARGUMENTS as typed.
+
+var (
+	claudeShellRe = regexp.MustCompile("!`([^`\n]*)`")
+	// A file reference starts a word and looks like a path, so that e-mail
+	// addresses and @mentions are left alone
+	claudeFileRe = regexp.MustCompile(`(^|[\s(\[])@((?:~|\.{1,2})?/?[\w.-]+(?:/[\w.-]+)*/?)`)
+	geminiFileRe = regexp.MustCompile(`@\{([^}]*)\}`)
+)
+
+const (
+	noteShellEscaped   = "Gemini shell-escapes {{args}} inside !{...} and passes it as one argument"
+	noteShellUnescaped = "Claude inserts $ARGUMENTS into the shell command as typed, without shell escaping"
+	noteShellPerms     = "Claude only runs it if the command's allowed-tools permits Bash"
+)
+
+// claudeToGeminiInjections rewrites Claude shell and file injections into
+// Gemini's syntax.
+func claudeToGeminiInjections(prompt string) (string, []promptRewrite) {
+	var rewrites []promptRewrite
+
+	prompt = claudeShellRe.ReplaceAllStringFunc(prompt, func(m string) string {
+		cmd := claudeShellRe.FindStringSubmatch(m)[1]
+		if !balancedBraces(cmd) {
+			rewrites = append(rewrites, promptRewrite{From: m, Lossy: true,
+				Note: "Gemini needs balanced braces inside !{...}, so the command will not run"})
+			return m
+		}
+
+		note := ""
+		if strings.Contains(cmd, "$ARGUMENTS") || len(positionalArgs(cmd)) > 0 {
+			cmd = strings.ReplaceAll(cmd, "$ARGUMENTS", "{{args}}")
+			cmd = positionalArgRe.ReplaceAllString(cmd, "{{args}}")
+			note = noteShellEscaped
+		}
+		out := "!{" + cmd + "}"
+		rewrites = append(rewrites, promptRewrite{From: m, To: out, Lossy: note != "", Note: note})
+		return out
+	})
+
+	prompt = claudeFileRe.ReplaceAllStringFunc(prompt, func(m string) string {
+		sub := claudeFileRe.FindStringSubmatch(m)
+		prefix, path := sub[1], sub[2]
+		// Sentence punctuation after a path is not part of it
+		trimmed := strings.TrimRight(path, ".")
+		if !strings.ContainsAny(trimmed, "./") || strings.Trim(trimmed, "./") == "" {
+			return m
+		}
+		out := "@{" + trimmed + "}"
+		rewrites = append(rewrites, promptRewrite{From: "@" + trimmed, To: out})
+		return prefix + out + path[len(trimmed):]
+	})
+
+	return prompt, rewrites
+}
+
+// geminiToClaudeInjections rewrites Gemini shell and file injections into
+// Claude's syntax.
+func geminiToClaudeInjections(prompt string) (string, []promptRewrite) {
+	var rewrites []promptRewrite
+
+	var b strings.Builder
+	rest := prompt
+	for {
+		start := strings.Index(rest, "!{")
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := matchingBrace(rest, start+1)
+		if end < 0 {
+			rewrites = append(rewrites, promptRewrite{From: rest[start:min(len(rest), start+20)], Lossy: true,
+				Note: "the shell injection has no closing brace"})
+			b.WriteString(rest)
+			break
+		}
+		original := rest[start : end+1]
+		cmd := rest[start+2 : end]
+		b.WriteString(rest[:start])
+		rest = rest[end+1:]
+
+		if strings.ContainsAny(cmd, "`\n") {
+			rewrites = append(rewrites, promptRewrite{From: original, Lossy: true,
+				Note: "Claude's !`...` cannot contain backticks or line breaks, so the command will not run"})
+			b.WriteString(original)
+			continue
+		}
+		out := "!`" + cmd + "`"
+		note := noteShellPerms
+		if strings.Contains(cmd, "{{args}}") {
+			note = noteShellUnescaped + "; " + noteShellPerms
+		}
+		rewrites = append(rewrites, promptRewrite{From: original, To: out, Lossy: true, Note: note})
+		b.WriteString(out)
+	}
+	prompt = b.String()
+
+	prompt = geminiFileRe.ReplaceAllStringFunc(prompt, func(m string) string {
+		path := geminiFileRe.FindStringSubmatch(m)[1]
+		if path == "" || strings.ContainsAny(path, " \t\n") {
+			rewrites = append(rewrites, promptRewrite{From: m, Lossy: true,
+				Note: "Claude's @path ends at the first space, so the file will not be included"})
+			return m
+		}
+		out := "@" + path
+		rewrites = append(rewrites, promptRewrite{From: m, To: out})
+		return out
+	})
+
+	return prompt, rewrites
+}
+
+// balancedBraces reports whether every { in s is closed, as Gemini requires
+// inside !{...}.
+func balancedBraces(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// matchingBrace returns the index of the } closing the { at open, or -1.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,154 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClaudeToGeminiInjections(t *testing.T) {
+	tests := []struct {
+		name      string
+		prompt    string
+		want      string
+		lossy     []string
+		roundtrip bool
+	}{
+		{
+			name:      "Shell and file",
+			prompt:    "Status: !`git status --short`\nSee @src/app.ts and @./README.md.",
+			want:      "Status: !{git status --short}\nSee @{src/app.ts} and @{./README.md}.",
+			roundtrip: true,
+		},
+		{
+			name:      "Mentions and e-mail are not files",
+			prompt:    "Ask @reviewer or mail dev@example.com about @docs/.",
+			want:      "Ask @reviewer or mail dev@example.com about @{docs/}.",
+			roundtrip: true,
+		},
+		{
+			name:   "Arguments in a shell command",
+			prompt: "!`gh pr view $1`",
+			want:   "!{gh pr view {{args}}}",
+			lossy:  []string{"!`gh pr view $1` became !{gh pr view {{args}}}; " + noteShellEscaped},
+		},
+		{
+			name:   "Unbalanced braces",
+			prompt: "!`awk '{print $1'`",
+			want:   "!`awk '{print $1'`",
+			lossy:  []string{"!`awk '{print $1'` was left as it is; Gemini needs balanced braces inside !{...}, so the command will not run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rewrites := claudeToGeminiInjections(tt.prompt)
+			if got != tt.want {
+				t.Errorf("Unexpected prompt:\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			var lossy []string
+			for _, r := range rewrites {
+				if r.Lossy {
+					lossy = append(lossy, r.String())
+				}
+			}
+			if strings.Join(lossy, "\n") != strings.Join(tt.lossy, "\n") {
+				t.Errorf("Expected lossy rewrites %v, got %v", tt.lossy, lossy)
+			}
+
+			if tt.roundtrip {
+				if back, _ := geminiToClaudeInjections(got); back != tt.prompt {
+					t.Errorf("Expected %q back, got %q", tt.prompt, back)
+				}
+			}
+		})
+	}
+}
+
+func TestGeminiToClaudeInjections(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+		lossy  []string
+	}{
+		{
+			name:   "Shell with arguments",
+			prompt: "Diff: !{git diff {{args}}}",
+			want:   "Diff: !`git diff {{args}}`",
+			lossy:  []string{"!{git diff {{args}}} became !`git diff {{args}}`; " + noteShellUnescaped + "; " + noteShellPerms},
+		},
+		{
+			name:   "Nested braces",
+			prompt: "!{jq '{name: .name}' package.json}",
+			want:   "!`jq '{name: .name}' package.json`",
+			lossy:  []string{"!{jq '{name: .name}' package.json} became !`jq '{name: .name}' package.json`; " + noteShellPerms},
+		},
+		{
+			name:   "Backticks",
+			prompt: "!{echo `date`}",
+			want:   "!{echo `date`}",
+			lossy:  []string{"!{echo `date`} was left as it is; Claude's !`...` cannot contain backticks or line breaks, so the command will not run"},
+		},
+		{
+			name:   "Unclosed",
+			prompt: "!{ls",
+			want:   "!{ls",
+			lossy:  []string{"!{ls was left as it is; the shell injection has no closing brace"},
+		},
+		{
+			name:   "Files",
+			prompt: "Read @{docs/guide.md} and @{My Notes.md}",
+			want:   "Read @docs/guide.md and @{My Notes.md}",
+			lossy:  []string{"@{My Notes.md} was left as it is; Claude's @path ends at the first space, so the file will not be included"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rewrites := geminiToClaudeInjections(tt.prompt)
+			if got != tt.want {
+				t.Errorf("Unexpected prompt:\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			var lossy []string
+			for _, r := range rewrites {
+				if r.Lossy {
+					lossy = append(lossy, r.String())
+				}
+			}
+			if strings.Join(lossy, "\n") != strings.Join(tt.lossy, "\n") {
+				t.Errorf("Expected lossy rewrites %v, got %v", tt.lossy, lossy)
+			}
+		})
+	}
+}
+
+func TestClaudeToGemini_CommandPreview(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "review.md"),
+		[]byte("---\ndescription: Review a file\n---\n\nReview @src/main.go given !`git log -1 $ARGUMENTS`\n"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Plan()
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(result.Commands) != 1 {
+		t.Fatalf("Expected 1 command preview, got %v", result.Commands)
+	}
+	preview := result.Commands[0]
+	if preview.Source != ".claude/commands/review.md" || preview.Target != "commands/review.toml" {
+		t.Errorf("Unexpected preview paths %s → %s", preview.Source, preview.Target)
+	}
+	if want := "Review @{src/main.go} given !{git log -1 {{args}}}"; preview.Prompt != want {
+		t.Errorf("Expected prompt %q, got %q", want, preview.Prompt)
+	}
+	if len(preview.Notes) != 1 || !strings.Contains(preview.Notes[0], noteShellEscaped) {
+		t.Errorf("Expected a note about shell escaping, got %v", preview.Notes)
+	}
+	if len(result.Warnings) != 1 || !strings.HasPrefix(result.Warnings[0], "commands/review.toml: ") {
+		t.Errorf("Expected the note as a warning, got %v", result.Warnings)
+	}
+}
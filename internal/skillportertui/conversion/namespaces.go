@@ -0,0 +1,96 @@
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Commands can live in subdirectories on both platforms. Gemini makes the
+// directory part of the name, so commands/git/commit.toml runs as
+// /git:commit. Claude Code only uses folders to group commands:
+// .claude/commands/git/commit.md still runs as /commit. Commands are named
+// here by their slash-separated path without the extension, "git/commit",
+// and keep that hierarchy in both directions. commands/agents/ is reserved
+// for converted subagents and is read separately.
+
+// claudeCommandsDir holds a standalone skill's slash commands.
+const claudeCommandsDir = ".claude/commands"
+
+// readClaudeCommands reads the slash commands under commandsDir, relative to
+// dir.
+func readClaudeCommands(dir, commandsDir string) ([]claudeCommand, error) {
+	root := filepath.Join(dir, filepath.FromSlash(commandsDir))
+	names, err := walkCommands(root, ".md")
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", commandsDir, err)
+	}
+	var commands []claudeCommand
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)+".md"))
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, claudeCommand{Name: name, Content: string(content)})
+	}
+	return commands, nil
+}
+
+// walkCommands returns the names of the command files with extension ext
+// under dir, in lexical order, without entering the top-level directories
+// in skip. A missing dir has no commands.
+func walkCommands(dir, ext string, skip ...string) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if containsString(skip, rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(rel, ext) {
+			names = append(names, strings.TrimSuffix(rel, ext))
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return names, err
+}
+
+// commandInvocation is how Gemini runs the command with the given name.
+func commandInvocation(name string) string {
+	return "/" + strings.ReplaceAll(name, "/", ":")
+}
+
+// claudeCommandCollisions groups the command names that Claude Code would
+// run under the same /name, keyed by that name.
+func claudeCommandCollisions(names []string) map[string][]string {
+	byBase := map[string][]string{}
+	for _, name := range names {
+		base := path.Base(name)
+		byBase[base] = append(byBase[base], name)
+	}
+	collisions := map[string][]string{}
+	for base, group := range byBase {
+		if len(group) > 1 {
+			sort.Strings(group)
+			collisions[base] = group
+		}
+	}
+	return collisions
+}
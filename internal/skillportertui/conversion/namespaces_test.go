@@ -0,0 +1,102 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClaudeToGemini_NamespacedCommands(t *testing.T) {
+	src := writeAgentSkill(t)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands", "git", "pr"), 0755)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands", "agents"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "git", "commit.md"), []byte("---\ndescription: Commit\n---\n\nCommit $ARGUMENTS\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "git", "pr", "open.md"), []byte("---\ndescription: Open a PR\n---\n\nOpen a PR\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "agents", "code-reviewer.md"), []byte("---\ndescription: Not an agent\n---\n\nReview\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "agents", "linter.md"), []byte("---\ndescription: Lint\n---\n\nLint\n"), 0644)
+
+	result, err := NewClaudeToGeminiConverter(src, "").Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	for _, file := range []string{"git/commit.toml", "git/pr/open.toml", "agents/code-reviewer.toml", "agents/linter.toml"} {
+		if !fileExists(filepath.Join(src, "commands", filepath.FromSlash(file))) {
+			t.Errorf("Expected commands/%s to be generated", file)
+		}
+	}
+
+	// The subagent owns commands/agents/code-reviewer.toml
+	data, _ := os.ReadFile(filepath.Join(src, "commands", "agents", "code-reviewer.toml"))
+	if !strings.Contains(string(data), "senior code reviewer") {
+		t.Errorf("Expected the subagent to win the collision, got:\n%s", data)
+	}
+
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, want := range []string{
+		"commands/agents/code-reviewer.toml: .claude/agents/code-reviewer.md and .claude/commands/agents/code-reviewer.md both become /agents:code-reviewer; skipped .claude/commands/agents/code-reviewer.md",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected warning %q, got:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestGeminiToClaude_NamespacedCommands(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"), []byte(`{"name": "demo", "version": "1.0.0", "description": "Demo"}`), 0644)
+	os.MkdirAll(filepath.Join(src, "commands", "git"), 0755)
+	os.MkdirAll(filepath.Join(src, "commands", "agents"), 0755)
+	os.WriteFile(filepath.Join(src, "commands", "commit.toml"), []byte("description = \"Commit\"\nprompt = \"Commit\"\n"), 0644)
+	os.WriteFile(filepath.Join(src, "commands", "git", "commit.toml"), []byte("description = \"Git commit\"\nprompt = \"Git commit\"\n"), 0644)
+	os.WriteFile(filepath.Join(src, "commands", "git", "log.toml"), []byte("description = \"Log\"\nprompt = \"Log\"\n"), 0644)
+	os.WriteFile(filepath.Join(src, "commands", "agents", "helper.toml"), []byte("description = \"Helper\"\nprompt = \"Help\"\nclaude-subagent = true\n"), 0644)
+	os.WriteFile(filepath.Join(src, "commands", "agents", "native.toml"), []byte("description = \"Native\"\nprompt = \"Native\"\n"), 0644)
+
+	out := t.TempDir()
+	result, err := NewGeminiToClaudeConverter(src, out).Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	for _, file := range []string{"commands/commit.md", "commands/git/commit.md", "commands/git/log.md", "agents/helper.md", "commands/agents/native.md"} {
+		if !fileExists(filepath.Join(out, ".claude", filepath.FromSlash(file))) {
+			t.Errorf("Expected .claude/%s to be generated", file)
+		}
+	}
+	if fileExists(filepath.Join(out, ".claude", "commands", "agents", "helper.md")) {
+		t.Error("Expected the marked subagent not to become a slash command")
+	}
+	if fileExists(filepath.Join(out, ".claude", "agents", "native.md")) {
+		t.Error("Expected the unmarked command not to become a subagent")
+	}
+
+	want := "/commit, /git:commit all run as /commit in Claude Code, which ignores command folders; rename all but one"
+	var collisions []string
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "Claude Code, which ignores command folders") {
+			collisions = append(collisions, w)
+		}
+	}
+	if len(collisions) != 1 || collisions[0] != want {
+		t.Errorf("Expected one collision warning %q, got %v", want, collisions)
+	}
+}
+
+func TestRoundtrip_NamespacedCommands(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands", "git"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "git", "commit.md"), []byte("---\ndescription: Commit\n---\n\nCommit $ARGUMENTS\n"), 0644)
+
+	report, err := Roundtrip(src, Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	for _, f := range report.Findings {
+		if strings.HasPrefix(f.Kind, "command-") {
+			t.Errorf("Expected the namespaced command to survive, got %v", f)
+		}
+	}
+}
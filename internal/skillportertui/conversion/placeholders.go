@@ -0,0 +1,164 @@
+package conversion
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Claude commands read their arguments as $ARGUMENTS or one at a time as $1,
+// $2, ...; Gemini commands only have {{args}}, the whole argument string.
+// $ARGUMENTS maps to {{args}} directly. Positional arguments cannot, so each
+// $N is replaced with a name and the prompt gains a preamble that tells the
+// model how to split {{args}} into those names. Converting back recognises
+// the preamble and restores the $N. Only $1 to $9 are arguments, so amounts
+// such as $100 or $5.50 are left alone, and a name the prompt already contains is not
+// used, so converting back only replaces what the preamble introduced.
+
+var (
+	positionalArgRe = regexp.MustCompile(`\$([1-9])(\.\d+)?\b`)
+	argumentHintRe  = regexp.MustCompile(`\[([^\]]*)\]|<([^>]*)>|(\S+)`)
+	argNameRe       = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+	argsPreambleRe  = regexp.MustCompile(`^Arguments: \{\{args\}\}\n\n` + regexp.QuoteMeta(argsSplitInstructions) + `\n((?:- <[^<>\n]+> is argument \d+\n)+)\n`)
+	argsMappingRe   = regexp.MustCompile(`- (<[^<>\n]+>) is argument (\d+)\n`)
+)
+
+const argsSplitInstructions = "Split the arguments above on spaces, keeping quoted text together as one argument. In this prompt:"
+
+// promptRewrite is one construct a prompt translation replaced, or left as
+// it was when To is empty.
+type promptRewrite struct {
+	From, To string
+	Lossy    bool
+	Note     string // Why the rewrite is lossy
+}
+
+func (r promptRewrite) String() string {
+	s := fmt.Sprintf("%s became %s", r.From, r.To)
+	if r.To == "" {
+		s = r.From + " was left as it is"
+	}
+	if r.Note != "" {
+		s += "; " + r.Note
+	}
+	return s
+}
+
+// translateClaudePrompt translates a Claude command prompt for Gemini:
+// shell and file injections first, so that arguments inside a shell command
+// are handled there, then argument placeholders.
+func translateClaudePrompt(prompt, argumentHint string) (string, []promptRewrite) {
+	prompt, rewrites := claudeToGeminiInjections(prompt)
+	prompt, more := claudeToGeminiPrompt(prompt, argumentHint)
+	return prompt, append(rewrites, more...)
+}
+
+// translateGeminiPrompt translates a Gemini command prompt for Claude.
+func translateGeminiPrompt(prompt string) (string, []promptRewrite) {
+	prompt, rewrites := geminiToClaudeInjections(prompt)
+	prompt, more := geminiToClaudePrompt(prompt)
+	return prompt, append(rewrites, more...)
+}
+
+// claudeToGeminiPrompt translates the placeholders of a Claude command
+// prompt. argumentHint, the command's argument-hint, names the positional
+// arguments; $N without a usable name becomes <argN>.
+func claudeToGeminiPrompt(prompt, argumentHint string) (string, []promptRewrite) {
+	var rewrites []promptRewrite
+	if strings.Contains(prompt, "$ARGUMENTS") {
+		prompt = strings.ReplaceAll(prompt, "$ARGUMENTS", "{{args}}")
+		rewrites = append(rewrites, promptRewrite{From: "$ARGUMENTS", To: "{{args}}"})
+	}
+
+	positions := positionalArgs(prompt)
+	if len(positions) == 0 {
+		return prompt, rewrites
+	}
+
+	names := argumentNames(argumentHint, positions[len(positions)-1], prompt)
+	prompt = positionalArgRe.ReplaceAllStringFunc(prompt, func(m string) string {
+		n, err := strconv.Atoi(m[1:])
+		if err != nil {
+			return m // An amount such as $5.50
+		}
+		return names[n]
+	})
+
+	var b strings.Builder
+	b.WriteString("Arguments: {{args}}\n\n")
+	b.WriteString(argsSplitInstructions + "\n")
+	for _, n := range positions {
+		fmt.Fprintf(&b, "- %s is argument %d\n", names[n], n)
+		rewrites = append(rewrites, promptRewrite{From: "$" + strconv.Itoa(n), To: names[n], Lossy: true,
+			Note: "Gemini passes all arguments as one {{args}} string, so the prompt now explains how to split it"})
+	}
+	b.WriteString("\n")
+	return b.String() + prompt, rewrites
+}
+
+// geminiToClaudePrompt translates the placeholders of a Gemini command
+// prompt, undoing the argument preamble claudeToGeminiPrompt adds.
+func geminiToClaudePrompt(prompt string) (string, []promptRewrite) {
+	var rewrites []promptRewrite
+	if m := argsPreambleRe.FindStringSubmatch(prompt); m != nil {
+		prompt = prompt[len(m[0]):]
+		for _, mapping := range argsMappingRe.FindAllStringSubmatch(m[1], -1) {
+			prompt = strings.ReplaceAll(prompt, mapping[1], "$"+mapping[2])
+			rewrites = append(rewrites, promptRewrite{From: mapping[1], To: "$" + mapping[2]})
+		}
+	}
+	if strings.Contains(prompt, "{{args}}") {
+		prompt = strings.ReplaceAll(prompt, "{{args}}", "$ARGUMENTS")
+		rewrites = append(rewrites, promptRewrite{From: "{{args}}", To: "$ARGUMENTS"})
+	}
+	return prompt, rewrites
+}
+
+// positionalArgs returns the argument numbers a prompt uses, in order.
+func positionalArgs(prompt string) []int {
+	seen := map[int]bool{}
+	var positions []int
+	for _, m := range positionalArgRe.FindAllStringSubmatch(prompt, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || m[2] != "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		positions = append(positions, n)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// argumentNames names the arguments 1..count after the words of an
+// argument-hint such as "[file] [reviewer]" or "<pr-number> [priority]",
+// skipping names that already appear in prompt.
+func argumentNames(hint string, count int, prompt string) map[int]string {
+	names := map[int]string{}
+	used := map[string]bool{}
+	for i, m := range argumentHintRe.FindAllStringSubmatch(hint, -1) {
+		if i+1 > count {
+			break
+		}
+		word := m[1] + m[2] + m[3]
+		word = strings.Trim(argNameRe.ReplaceAllString(strings.TrimSpace(word), "-"), "-.")
+		if word == "" || used[word] || strings.Contains(prompt, "<"+word+">") {
+			continue
+		}
+		used[word] = true
+		names[i+1] = "<" + word + ">"
+	}
+	for n := 1; n <= count; n++ {
+		if names[n] != "" {
+			continue
+		}
+		name := fmt.Sprintf("<arg%d>", n)
+		for i := 2; strings.Contains(prompt, name); i++ {
+			name = fmt.Sprintf("<arg%d-%d>", n, i)
+		}
+		names[n] = name
+	}
+	return names
+}
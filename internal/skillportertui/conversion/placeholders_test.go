@@ -0,0 +1,110 @@
+package conversion
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClaudeToGeminiPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt string
+		hint   string
+		want   string
+		lossy  []string
+	}{
+		{
+			name:   "All arguments",
+			prompt: "Fix $ARGUMENTS",
+			want:   "Fix {{args}}",
+		},
+		{
+			name:   "Named by argument-hint",
+			prompt: "Review $1 and ask $2 to approve.",
+			hint:   "[file] <reviewer>",
+			want: "Arguments: {{args}}\n\n" + argsSplitInstructions + "\n" +
+				"- <file> is argument 1\n- <reviewer> is argument 2\n\n" +
+				"Review <file> and ask <reviewer> to approve.",
+			lossy: []string{"$1 → <file>", "$2 → <reviewer>"},
+		},
+		{
+			name:   "Short hint",
+			prompt: "Compare $2 with $1, then $3",
+			hint:   "[base branch]",
+			want: "Arguments: {{args}}\n\n" + argsSplitInstructions + "\n" +
+				"- <base-branch> is argument 1\n- <arg2> is argument 2\n- <arg3> is argument 3\n\n" +
+				"Compare <arg2> with <base-branch>, then <arg3>",
+			lossy: []string{"$1 → <base-branch>", "$2 → <arg2>", "$3 → <arg3>"},
+		},
+		{
+			name:   "Mixed with all arguments",
+			prompt: "Issue $1: $ARGUMENTS",
+			want: "Arguments: {{args}}\n\n" + argsSplitInstructions + "\n" +
+				"- <arg1> is argument 1\n\n" +
+				"Issue <arg1>: {{args}}",
+			lossy: []string{"$1 → <arg1>"},
+		},
+		{
+			name:   "Amounts are not arguments",
+			prompt: "It costs $100, or $5.50 with $1 off",
+			want: "Arguments: {{args}}\n\n" + argsSplitInstructions + "\n" +
+				"- <arg1> is argument 1\n\n" +
+				"It costs $100, or $5.50 with <arg1> off",
+			lossy: []string{"$1 → <arg1>"},
+		},
+		{
+			name:   "No arguments in prose",
+			prompt: "Budget: $250",
+			want:   "Budget: $250",
+		},
+		{
+			name:   "Name already in the prompt",
+			prompt: "Edit $1 as described in <file>, then $2 <arg2>",
+			hint:   "[file]",
+			want: "Arguments: {{args}}\n\n" + argsSplitInstructions + "\n" +
+				"- <arg1> is argument 1\n- <arg2-2> is argument 2\n\n" +
+				"Edit <arg1> as described in <file>, then <arg2-2> <arg2>",
+			lossy: []string{"$1 → <arg1>", "$2 → <arg2-2>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rewrites := claudeToGeminiPrompt(tt.prompt, tt.hint)
+			if got != tt.want {
+				t.Errorf("Unexpected prompt:\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+			var lossy []string
+			for _, r := range rewrites {
+				if r.Lossy {
+					lossy = append(lossy, r.From+" → "+r.To)
+				}
+			}
+			if strings.Join(lossy, ", ") != strings.Join(tt.lossy, ", ") {
+				t.Errorf("Expected lossy rewrites %v, got %v", tt.lossy, lossy)
+			}
+
+			// Converting back restores the original placeholders
+			if back, _ := geminiToClaudePrompt(got); back != tt.prompt {
+				t.Errorf("Expected %q back, got %q", tt.prompt, back)
+			}
+		})
+	}
+}
+
+func TestGeminiToClaudePrompt(t *testing.T) {
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"Fix {{args}}", "Fix $ARGUMENTS"},
+		{"No arguments", "No arguments"},
+		// A preamble written by hand is only recognised in the exact form
+		{"Arguments: {{args}}\n\nUse <file>", "Arguments: $ARGUMENTS\n\nUse <file>"},
+	}
+	for _, tt := range tests {
+		if got, _ := geminiToClaudePrompt(tt.prompt); got != tt.want {
+			t.Errorf("geminiToClaudePrompt(%q) = %q, want %q", tt.prompt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package conversion
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// previewLines and previewWidth bound the content preview of a planned file.
+const (
+	previewLines = 3
+	previewWidth = 72
+)
+
+// PlannedFile is a file a conversion will write.
+type PlannedFile struct {
+	Path    string // Relative to the plan's output directory, slash-separated
+	Content []byte
+	Mode    fs.FileMode
+}
+
+// Plan is the set of files a conversion will write. Converters build a plan
+// instead of writing directly, so the output can be previewed before it
+// touches disk and then applied in one step.
+type Plan struct {
+	OutputDir string
+
+	files []PlannedFile
+	index map[string]int
+	err   error // The first path rejected by Add
+}
+
+// NewPlan creates an empty plan for outputDir.
+func NewPlan(outputDir string) *Plan {
+	return &Plan{OutputDir: outputDir, index: make(map[string]int)}
+}
+
+// Add schedules a file write, replacing any earlier entry for the same path,
+// and returns the absolute destination path. A path that is absolute or
+// leaves the output directory is not planned; it returns "" and is reported
+// by Err.
+func (p *Plan) Add(rel string, content []byte, mode fs.FileMode) string {
+	rel = path.Clean(filepath.ToSlash(rel))
+	if path.IsAbs(rel) || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+		if p.err == nil {
+			p.err = fmt.Errorf("%s is outside the output directory", rel)
+		}
+		return ""
+	}
+	f := PlannedFile{Path: rel, Content: content, Mode: mode}
+	if i, ok := p.index[rel]; ok {
+		p.files[i] = f
+	} else {
+		p.index[rel] = len(p.files)
+		p.files = append(p.files, f)
+	}
+	return p.Abs(rel)
+}
+
+// Err returns the first path Add rejected, or nil. A plan with an error is
+// never applied.
+func (p *Plan) Err() error {
+	return p.err
+}
+
+// Remove drops a file from the plan so that Apply leaves it untouched.
+func (p *Plan) Remove(rel string) {
+	rel = path.Clean(filepath.ToSlash(rel))
+	i, ok := p.index[rel]
+	if !ok {
+		return
+	}
+	p.files = append(p.files[:i], p.files[i+1:]...)
+	delete(p.index, rel)
+	for j := i; j < len(p.files); j++ {
+		p.index[p.files[j].Path] = j
+	}
+}
+
+// Files returns the planned files in the order they were added.
+func (p *Plan) Files() []PlannedFile {
+	return p.files
+}
+
+// Abs returns the destination path of a relative plan path.
+func (p *Plan) Abs(rel string) string {
+	return filepath.Join(p.OutputDir, filepath.FromSlash(rel))
+}
+
+// Exists reports whether rel is a file or directory either in the plan or
+// already on disk under the output directory.
+func (p *Plan) Exists(rel string) bool {
+	rel = path.Clean(filepath.ToSlash(rel))
+	if _, ok := p.index[rel]; ok {
+		return true
+	}
+	for _, f := range p.files {
+		if strings.HasPrefix(f.Path, rel+"/") {
+			return true
+		}
+	}
+	_, err := os.Stat(p.Abs(rel))
+	return err == nil
+}
+
+// addTree schedules a copy of every regular file under src at the same place
+// under dest, preserving file modes. With keep set, files already planned are
+// left as they are. A nested output directory is skipped so the copy does not
+// recurse into itself, and so are staging directories.
+func (p *Plan) addTree(src, dest string, keep bool) error {
+	absOut, _ := filepath.Abs(p.OutputDir)
+
+	return filepath.WalkDir(src, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if abs, _ := filepath.Abs(file); abs == absOut || (file != src && strings.HasPrefix(d.Name(), stagePrefix)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		return p.addFile(file, path.Join(dest, filepath.ToSlash(rel)), info.Mode(), keep)
+	})
+}
+
+// Changes compares the plan with what is on disk and describes what applying
+// it would do to each file.
+func (p *Plan) Changes() []domain.PlannedChange {
+	changes := make([]domain.PlannedChange, 0, len(p.files))
+	for _, f := range p.files {
+		change := domain.PlannedChange{
+			Path:    f.Path,
+			Action:  domain.ActionCreate,
+			Size:    int64(len(f.Content)),
+			Preview: preview(f.Content),
+		}
+		if existing, err := os.ReadFile(p.Abs(f.Path)); err == nil {
+			change.ExistingSize = int64(len(existing))
+			if bytes.Equal(existing, f.Content) {
+				change.Action = domain.ActionUnchanged
+			} else {
+				change.Action = domain.ActionOverwrite
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// Diff returns a unified diff of the file on disk against the planned
+// content of rel, or "" when the file is new or unchanged.
+func (p *Plan) Diff(rel string) string {
+	i, ok := p.index[path.Clean(filepath.ToSlash(rel))]
+	if !ok {
+		return ""
+	}
+	f := p.files[i]
+	existing, err := os.ReadFile(p.Abs(f.Path))
+	if err != nil {
+		return ""
+	}
+	return UnifiedDiff(f.Path, existing, f.Content)
+}
+
+// preview returns the first few lines of content, each truncated.
+func preview(content []byte) string {
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) > previewLines {
+		lines = append(lines[:previewLines], "…")
+	}
+	for i, line := range lines {
+		if r := []rune(line); len(r) > previewWidth {
+			lines[i] = string(r[:previewWidth]) + "…"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,140 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestPlan_Changes(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "same.txt"), []byte("same\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "old.txt"), []byte("old\n"), 0644)
+
+	plan := NewPlan(dir)
+	plan.Add("new.txt", []byte("one\ntwo\nthree\nfour\n"), 0644)
+	plan.Add("old.txt", []byte("changed\n"), 0644)
+	plan.Add("same.txt", []byte("same\n"), 0644)
+
+	changes := plan.Changes()
+	tests := []struct {
+		path         string
+		action       domain.FileAction
+		size         int64
+		existingSize int64
+	}{
+		{"new.txt", domain.ActionCreate, 19, 0},
+		{"old.txt", domain.ActionOverwrite, 8, 4},
+		{"same.txt", domain.ActionUnchanged, 5, 5},
+	}
+	if len(changes) != len(tests) {
+		t.Fatalf("Expected %d changes, got %v", len(tests), changes)
+	}
+	for i, tt := range tests {
+		c := changes[i]
+		if c.Path != tt.path || c.Action != tt.action || c.Size != tt.size || c.ExistingSize != tt.existingSize {
+			t.Errorf("Expected %+v, got %+v", tt, c)
+		}
+	}
+	if changes[0].Preview != "one\ntwo\nthree\n…" {
+		t.Errorf("Expected truncated preview, got %q", changes[0].Preview)
+	}
+
+	// Planning alone must not touch disk
+	if fileExists(filepath.Join(dir, "new.txt")) {
+		t.Error("Expected new.txt not to be written before Apply")
+	}
+}
+
+func TestPlan_RemoveAndDiff(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("old\n"), 0644)
+
+	plan := NewPlan(dir)
+	plan.Add("a.txt", []byte("new\n"), 0644)
+	plan.Add("b.txt", []byte("b\n"), 0644)
+	plan.Add("c.txt", []byte("c\n"), 0644)
+
+	if got := plan.Diff("a.txt"); got != "--- a/a.txt\n+++ b/a.txt\n@@ -1,1 +1,1 @@\n-old\n+new\n" {
+		t.Errorf("Unexpected diff for a.txt: %q", got)
+	}
+	if got := plan.Diff("b.txt"); got != "" {
+		t.Errorf("Expected no diff for a new file, got %q", got)
+	}
+
+	plan.Remove("a.txt")
+	plan.Remove("missing.txt")
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "a.txt")); string(data) != "old\n" {
+		t.Errorf("Expected removed file to be left alone, got %q", data)
+	}
+	for _, f := range []string{"b.txt", "c.txt"} {
+		if !fileExists(filepath.Join(dir, f)) {
+			t.Errorf("Expected %s to be written", f)
+		}
+	}
+}
+
+func TestPlan_RejectsPathsOutsideOutput(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+
+	for _, rel := range []string{"../escape.txt", "a/../../escape.txt", "/etc/escape.txt"} {
+		plan := NewPlan(dir)
+		plan.Add("ok.txt", []byte("ok\n"), 0644)
+		if got := plan.Add(rel, []byte("bad\n"), 0644); got != "" {
+			t.Errorf("Expected %s to be rejected, got %s", rel, got)
+		}
+		if plan.Err() == nil {
+			t.Errorf("Expected an error for %s", rel)
+		}
+		if err := plan.Apply(); err == nil {
+			t.Errorf("Expected Apply to fail for %s", rel)
+		}
+		if fileExists(filepath.Join(dir, "ok.txt")) {
+			t.Errorf("Expected nothing to be written for %s", rel)
+		}
+	}
+	if fileExists(filepath.Join(filepath.Dir(dir), "escape.txt")) {
+		t.Error("Expected no file outside the output directory")
+	}
+}
+
+func TestPlanConversion_DoesNotWrite(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+
+	result, err := PlanConversion(src, domain.TargetGemini, "", Options{})
+	if err != nil {
+		t.Fatalf("PlanConversion failed: %v", err)
+	}
+	if result.Plan == nil || len(result.Plan.Files()) == 0 {
+		t.Fatal("Expected planned files")
+	}
+	if fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Expected dry run to leave the source tree untouched")
+	}
+	for _, c := range result.Plan.Changes() {
+		if c.Path == "SKILL.md" {
+			t.Errorf("Expected SKILL.md not to be part of a Claude -> Gemini plan")
+		}
+		if c.Path == "gemini-extension.json" && c.Action != domain.ActionCreate {
+			t.Errorf("Expected gemini-extension.json to be created, got %s", c.Action)
+		}
+	}
+
+	if err := result.Plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if !fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Expected Apply to write gemini-extension.json")
+	}
+	for _, c := range result.Plan.Changes() {
+		if c.Action != domain.ActionUnchanged {
+			t.Errorf("Expected %s to be unchanged after Apply, got %s", c.Path, c.Action)
+		}
+	}
+}
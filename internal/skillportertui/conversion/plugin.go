@@ -0,0 +1,246 @@
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+)
+
+// A Claude Code plugin bundles several skills with the commands, subagents
+// and MCP servers they share:
+//
+//	.claude-plugin/plugin.json   name, version, description
+//	.mcp.json                    MCP servers, paths under ${CLAUDE_PLUGIN_ROOT}
+//	skills/<name>/SKILL.md       one directory per skill
+//	commands/, agents/           slash commands and subagents
+//
+// By default the plugin becomes one Gemini extension with a GEMINI.md section
+// per skill. Split, each skill becomes an extension of its own in its
+// skills/<name>/ directory, and the extension at the plugin root keeps the
+// shared commands, agents and MCP servers.
+
+const (
+	pluginManifestFile = ".claude-plugin/plugin.json"
+	pluginSkillsDir    = "skills"
+	pluginCommandsDir  = "commands"
+	pluginAgentsDir    = "agents"
+)
+
+// PluginToGeminiConverter converts a Claude Code plugin into one Gemini
+// extension, or into one per skill when Options.SplitPlugins is set.
+type PluginToGeminiConverter struct {
+	SourcePath string
+	OutputPath string
+	Options    Options
+}
+
+// NewPluginToGeminiConverter creates a converter. An empty outputPath
+// converts in place.
+func NewPluginToGeminiConverter(sourcePath, outputPath string) *PluginToGeminiConverter {
+	if outputPath == "" {
+		outputPath = sourcePath
+	}
+	return &PluginToGeminiConverter{SourcePath: sourcePath, OutputPath: outputPath}
+}
+
+// Convert performs the conversion and returns the generated files.
+func (p *PluginToGeminiConverter) Convert() (*Result, error) {
+	result, err := p.Plan()
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Plan.Apply(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Plan builds the conversion without writing anything to disk.
+func (p *PluginToGeminiConverter) Plan() (*Result, error) {
+	return p.planInto(NewPlan(p.OutputPath))
+}
+
+// pluginSkill is one skills/<name>/SKILL.md of a plugin.
+type pluginSkill struct {
+	Dir         string // Relative to the plugin, e.g. skills/pdf
+	Frontmatter skillFrontmatter
+	Body        string
+}
+
+func (p *PluginToGeminiConverter) planInto(plan *Plan) (*Result, error) {
+	manifest, err := jsonobj.Read(filepath.Join(p.SourcePath, filepath.FromSlash(pluginManifestFile)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", pluginManifestFile, err)
+	}
+	name := manifest.String("name")
+	if name == "" {
+		return nil, fmt.Errorf("%s has no name", pluginManifestFile)
+	}
+	skills, err := readPluginSkills(p.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension at the plugin root is built by the skill converter from
+	// the plugin's own files
+	c := &ClaudeToGeminiConverter{
+		SourcePath:     p.SourcePath,
+		OutputPath:     plan.OutputDir,
+		Options:        p.Options,
+		commandsDir:    pluginCommandsDir,
+		plan:           plan,
+		pluginManifest: manifest,
+		contextRebased: true,
+		frontmatter:    skillFrontmatter{Name: name, Description: manifest.String("description")},
+	}
+	if c.commands, err = readClaudeCommands(p.SourcePath, pluginCommandsDir); err != nil {
+		return nil, err
+	}
+	var agentWarnings []string
+	if c.agents, agentWarnings, err = readClaudeAgents(p.SourcePath, pluginAgentsDir); err != nil {
+		return nil, err
+	}
+	c.warnings = append(c.warnings, agentWarnings...)
+	if mcpFile, err := jsonobj.Read(filepath.Join(p.SourcePath, ".mcp.json")); err == nil {
+		c.mcpConfig = mcpFile.Object("mcpServers")
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("invalid .mcp.json: %w", err)
+	}
+
+	if !p.Options.SplitPlugins {
+		c.content = p.mergeSkills(c, skills)
+		return c.generate(&Result{Source: domain.PlatformClaudePlugin, Plan: plan})
+	}
+
+	var parts []*Result
+	var b strings.Builder
+	b.WriteString("Each skill of this plugin is a Gemini extension of its own:\n\n")
+	for _, skill := range skills {
+		part, err := p.planSkill(plan, skill)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+		fmt.Fprintf(&b, "- **%s** in `%s/`: %s\n", skill.Frontmatter.Name, skill.Dir, skill.Frontmatter.Description)
+	}
+	c.content = b.String()
+
+	result, err := c.generate(&Result{Source: domain.PlatformClaudePlugin, Plan: plan})
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		result.Files = append(result.Files, part.Files...)
+		result.Warnings = append(result.Warnings, part.Warnings...)
+		result.MCPRewrites = append(result.MCPRewrites, part.MCPRewrites...)
+		result.Settings = append(result.Settings, part.Settings...)
+		result.Lossy = append(result.Lossy, part.Lossy...)
+		result.Commands = append(result.Commands, part.Commands...)
+		result.MissingFiles = append(result.MissingFiles, part.MissingFiles...)
+	}
+	return result, nil
+}
+
+// mergeSkills returns the GEMINI.md content of a single extension holding
+// every skill, with each skill's links rebased onto GEMINI.md. A skill's
+// allowed-tools cannot apply to the other skills, so it is reported lost.
+func (p *PluginToGeminiConverter) mergeSkills(c *ClaudeToGeminiConverter, skills []pluginSkill) string {
+	var sections []string
+	for _, skill := range skills {
+		file := skill.Dir + "/SKILL.md"
+		body, refs, missing := rebaseLinks(skill.Body, p.SourcePath, file, c.OutputPath, "GEMINI.md")
+		c.assets = append(append(c.assets, skill.Dir), refs...)
+		for _, m := range missing {
+			c.missing = append(c.missing, file+": "+m)
+		}
+		c.frontmatter.Subagents = append(c.frontmatter.Subagents, skill.Frontmatter.Subagents...)
+		if len(skill.Frontmatter.AllowedTools) > 0 {
+			c.lossy = append(c.lossy, domain.LossyField{File: file, Field: "allowed-tools",
+				Value:  strings.Join(skill.Frontmatter.AllowedTools, ", "),
+				Reason: "one Gemini extension holds every skill of the plugin, so tools cannot be restricted per skill; split the plugin to keep them"})
+		}
+		sections = append(sections, fmt.Sprintf("## %s\n\n%s\n\n%s", skill.Frontmatter.Name, skill.Frontmatter.Description, strings.TrimSpace(body)))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// planSkill converts one skill into an extension in its own directory and
+// adds the files to plan. Paths in the result are made relative to the
+// plugin.
+func (p *PluginToGeminiConverter) planSkill(plan *Plan, skill pluginSkill) (*Result, error) {
+	sub := NewPlan(filepath.Join(plan.OutputDir, filepath.FromSlash(skill.Dir)))
+	c := NewClaudeToGeminiConverter(filepath.Join(p.SourcePath, filepath.FromSlash(skill.Dir)), sub.OutputDir)
+	c.Options = p.Options
+	result, err := c.planInto(sub)
+	if err == nil {
+		err = sub.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", skill.Dir, err)
+	}
+	for _, f := range sub.Files() {
+		plan.Add(path.Join(skill.Dir, f.Path), f.Content, f.Mode)
+	}
+
+	for i, w := range result.Warnings {
+		result.Warnings[i] = skill.Dir + "/" + w
+	}
+	for i := range result.Lossy {
+		result.Lossy[i].File = path.Join(skill.Dir, result.Lossy[i].File)
+	}
+	for i := range result.Commands {
+		result.Commands[i].Source = path.Join(skill.Dir, result.Commands[i].Source)
+		result.Commands[i].Target = path.Join(skill.Dir, result.Commands[i].Target)
+	}
+	for i, m := range result.MissingFiles {
+		result.MissingFiles[i] = skill.Dir + "/" + m
+	}
+	return result, nil
+}
+
+// readPluginSkills reads skills/*/SKILL.md under dir, in directory order.
+// The directory name is used when the frontmatter has no name.
+func readPluginSkills(dir string) ([]pluginSkill, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, pluginSkillsDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var skills []pluginSkill
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		rel := pluginSkillsDir + "/" + entry.Name()
+		data, err := os.ReadFile(filepath.Join(dir, pluginSkillsDir, entry.Name(), "SKILL.md"))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fm, err := ParseFrontmatter(data)
+		if errors.Is(err, ErrNoFrontmatter) {
+			return nil, fmt.Errorf("%s/SKILL.md missing YAML frontmatter", rel)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s/SKILL.md frontmatter: %w", rel, err)
+		}
+		skill := pluginSkill{Dir: rel, Body: fm.Body()}
+		if err := fm.Decode(&skill.Frontmatter); err != nil {
+			return nil, fmt.Errorf("invalid %s/SKILL.md frontmatter: %w", rel, err)
+		}
+		if skill.Frontmatter.Name == "" {
+			skill.Frontmatter.Name = entry.Name()
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
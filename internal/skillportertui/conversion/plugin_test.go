@@ -0,0 +1,142 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+)
+
+// writePlugin creates a Claude Code plugin with two skills, a command, a
+// subagent and an MCP server.
+func writePlugin(t *testing.T) string {
+	t.Helper()
+	src := t.TempDir()
+	for _, dir := range []string{".claude-plugin", "skills/pdf/scripts", "skills/xlsx", "commands", "agents"} {
+		os.MkdirAll(filepath.Join(src, filepath.FromSlash(dir)), 0755)
+	}
+	os.WriteFile(filepath.Join(src, ".claude-plugin", "plugin.json"), []byte(`{"name": "docs", "version": "0.3.0", "description": "Document tools"}`), 0644)
+	os.WriteFile(filepath.Join(src, ".mcp.json"), []byte(`{"mcpServers": {"docs": {"command": "node", "args": ["${CLAUDE_PLUGIN_ROOT}/server/index.js"]}}}`), 0644)
+	os.WriteFile(filepath.Join(src, "skills", "pdf", "SKILL.md"), []byte("---\nname: pdf\ndescription: Fill PDF forms\nallowed-tools: Read, Bash\n---\n\nRun [the filler](scripts/fill.py).\n"), 0644)
+	os.WriteFile(filepath.Join(src, "skills", "pdf", "scripts", "fill.py"), []byte("#!/usr/bin/env python3\n"), 0755)
+	os.WriteFile(filepath.Join(src, "skills", "xlsx", "SKILL.md"), []byte("---\nname: xlsx\ndescription: Edit spreadsheets\n---\n\nUse formulas.\n"), 0644)
+	os.WriteFile(filepath.Join(src, "commands", "summarize.md"), []byte("---\ndescription: Summarize a document\n---\n\nSummarize $ARGUMENTS\n"), 0644)
+	os.WriteFile(filepath.Join(src, "agents", "reviewer.md"), []byte("---\nname: reviewer\ndescription: Reviews documents\n---\n\nYou review documents.\n"), 0644)
+	return src
+}
+
+func TestPluginToGemini_SingleExtension(t *testing.T) {
+	src := writePlugin(t)
+	out := filepath.Join(t.TempDir(), "docs")
+
+	result, err := PlanConversion(src, domain.TargetGemini, out, Options{})
+	if err != nil {
+		t.Fatalf("PlanConversion failed: %v", err)
+	}
+	if result.Source != domain.PlatformClaudePlugin {
+		t.Errorf("Expected source %s, got %s", domain.PlatformClaudePlugin, result.Source)
+	}
+	if err := result.Plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	manifest, err := jsonobj.Read(filepath.Join(out, "gemini-extension.json"))
+	if err != nil {
+		t.Fatalf("Expected a single gemini-extension.json: %v", err)
+	}
+	if manifest.String("name") != "docs" || manifest.String("version") != "0.3.0" {
+		t.Errorf("Expected the plugin's name and version, got %s", manifest)
+	}
+	args, _ := manifest.Object("mcpServers").Object("docs").Get("args")
+	if got := args.([]any)[0]; got != "${extensionPath}/server/index.js" {
+		t.Errorf("Expected the plugin root to become the extension path, got %v", got)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(out, "GEMINI.md"))
+	for _, want := range []string{"## pdf", "## xlsx", "[the filler](skills/pdf/scripts/fill.py)"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected GEMINI.md to contain %q, got:\n%s", want, data)
+		}
+	}
+	for _, file := range []string{"commands/summarize.toml", "commands/agents/reviewer.toml", "skills/pdf/scripts/fill.py"} {
+		if !fileExists(filepath.Join(out, filepath.FromSlash(file))) {
+			t.Errorf("Expected %s to be generated", file)
+		}
+	}
+	if fileExists(filepath.Join(out, "skills", "pdf", "gemini-extension.json")) {
+		t.Error("Expected no per-skill extension without splitting")
+	}
+
+	if len(result.Lossy) != 1 || result.Lossy[0].File != "skills/pdf/SKILL.md" || result.Lossy[0].Field != "allowed-tools" {
+		t.Errorf("Expected the skill's allowed-tools to be reported lost, got %v", result.Lossy)
+	}
+}
+
+func TestPluginToGemini_Split(t *testing.T) {
+	src := writePlugin(t)
+	out := filepath.Join(t.TempDir(), "docs")
+
+	c := NewPluginToGeminiConverter(src, out)
+	c.Options.SplitPlugins = true
+	result, err := c.Convert()
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	for _, skill := range []string{"pdf", "xlsx"} {
+		manifest, err := jsonobj.Read(filepath.Join(out, "skills", skill, "gemini-extension.json"))
+		if err != nil {
+			t.Fatalf("Expected an extension for %s: %v", skill, err)
+		}
+		if manifest.String("name") != skill {
+			t.Errorf("Expected extension name %q, got %q", skill, manifest.String("name"))
+		}
+	}
+	pdf, _ := jsonobj.Read(filepath.Join(out, "skills", "pdf", "gemini-extension.json"))
+	if _, ok := pdf.Get("excludeTools"); !ok {
+		t.Error("Expected the split skill to keep its tool restrictions")
+	}
+	if !fileExists(filepath.Join(out, "skills", "pdf", "scripts", "fill.py")) {
+		t.Error("Expected the skill's scripts to be copied")
+	}
+
+	// The shared parts stay with the extension at the plugin root
+	root, err := jsonobj.Read(filepath.Join(out, "gemini-extension.json"))
+	if err != nil {
+		t.Fatalf("Expected a root gemini-extension.json: %v", err)
+	}
+	if root.Object("mcpServers").Object("docs") == nil {
+		t.Errorf("Expected the plugin's MCP server at the root, got %s", root)
+	}
+	if !fileExists(filepath.Join(out, "commands", "summarize.toml")) {
+		t.Error("Expected the plugin's commands at the root")
+	}
+	data, _ := os.ReadFile(filepath.Join(out, "GEMINI.md"))
+	if !strings.Contains(string(data), "**pdf** in `skills/pdf/`") {
+		t.Errorf("Expected the root GEMINI.md to list the skills, got:\n%s", data)
+	}
+	for _, l := range result.Lossy {
+		if strings.Contains(l.Reason, "holds every skill") {
+			t.Errorf("Expected tool restrictions to be kept when splitting, got %v", l)
+		}
+	}
+}
+
+func TestPlanConversion_Plugin(t *testing.T) {
+	src := writePlugin(t)
+
+	result, err := PlanConversion(src, domain.TargetClaude, "", Options{})
+	if err != nil {
+		t.Fatalf("PlanConversion failed: %v", err)
+	}
+	if result.Plan != nil || result.Message == "" {
+		t.Errorf("Expected no conversion for a plugin targeting Claude, got %+v", result)
+	}
+
+	if _, err := Roundtrip(src, Options{}); err == nil {
+		t.Error("Expected round trips of plugins to be rejected, got nil")
+	}
+}
@@ -0,0 +1,201 @@
+package conversion
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// Result describes the outcome of a conversion.
+type Result struct {
+	// Source is the platform the skill was detected as before converting.
+	Source   string
+	Files    []string
+	Warnings []string
+	// MCPRewrites lists every change made to MCP server configs.
+	MCPRewrites []domain.MCPRewrite
+	// Settings lists the extension settings inferred from MCP server
+	// environments, for review before they are written.
+	Settings []domain.InferredSetting
+	// Lossy lists source fields the target platform cannot express.
+	Lossy []domain.LossyField
+	// Commands previews each translated command prompt.
+	Commands []domain.CommandPreview
+	// MissingFiles lists the files the skill references that do not exist,
+	// as "<file>: <path>".
+	MissingFiles []string
+	// Message is set when no conversion was necessary.
+	Message string
+	// Plan holds the files the conversion writes; nil when nothing is written.
+	Plan *Plan
+}
+
+// ConversionResult converts r into the form the UI and reports render.
+// Validation findings are added by the caller once the output is on disk.
+func (r *Result) ConversionResult(target domain.ConversionTarget, duration time.Duration) *domain.ConversionResult {
+	return &domain.ConversionResult{
+		SourcePlatform: r.Source,
+		Target:         target,
+		Files:          r.Files,
+		Warnings:       r.Warnings,
+		Message:        r.Message,
+		MCPRewrites:    r.MCPRewrites,
+		Settings:       r.Settings,
+		Lossy:          r.Lossy,
+		Commands:       r.Commands,
+		MissingFiles:   r.MissingFiles,
+		Duration:       duration,
+	}
+}
+
+// FormatLossReport renders the source fields a conversion of the skill at
+// skillPath to target drops.
+func FormatLossReport(skillPath string, target domain.ConversionTarget, r *Result) string {
+	if r.Message != "" {
+		return fmt.Sprintf("%s: %s\n", skillPath, r.Message)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s → %s, %d field(s) dropped\n", skillPath, r.Source, target, len(r.Lossy))
+	for _, l := range r.Lossy {
+		fmt.Fprintf(&b, "  - %s\n", l)
+	}
+	return b.String()
+}
+
+// Convert is the in-process equivalent of `skill-porter convert`. It detects
+// the source platform, skips conversions that are not needed, and runs the
+// matching converter. An empty outputPath converts in place.
+func Convert(sourcePath string, target domain.ConversionTarget, outputPath string, opts Options) (*Result, error) {
+	result, err := PlanConversion(sourcePath, target, outputPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if result.Plan != nil {
+		if err := result.Plan.Apply(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// PlanConversion works out what Convert would write without touching disk.
+func PlanConversion(sourcePath string, target domain.ConversionTarget, outputPath string, opts Options) (*Result, error) {
+	result, err := planConversion(sourcePath, target, outputPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	if result.Plan != nil {
+		if err := result.Plan.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func planConversion(sourcePath string, target domain.ConversionTarget, outputPath string, opts Options) (*Result, error) {
+	if sourcePath == "" {
+		return nil, fmt.Errorf("input path is required")
+	}
+
+	hasClaude := fileExists(filepath.Join(sourcePath, "SKILL.md"))
+	hasGemini := fileExists(filepath.Join(sourcePath, "gemini-extension.json"))
+	if !hasClaude && isClaudePlugin(sourcePath) {
+		return planPlugin(sourcePath, target, hasGemini, outputPath, opts)
+	}
+
+	switch {
+	case hasClaude && hasGemini:
+		return &Result{Source: domain.PlatformUniversal, Message: "Already a universal skill/extension - no conversion needed"}, nil
+	case !hasClaude && !hasGemini:
+		return nil, fmt.Errorf("unable to detect platform type; ensure directory contains valid skill/extension files")
+	}
+
+	switch target {
+	case domain.TargetGemini:
+		if hasGemini {
+			return &Result{Source: domain.PlatformGemini, Message: "Already a gemini extension - no conversion needed"}, nil
+		}
+		c := NewClaudeToGeminiConverter(sourcePath, outputPath)
+		c.Options = opts
+		return c.Plan()
+	case domain.TargetClaude:
+		if hasClaude {
+			return &Result{Source: domain.PlatformClaude, Message: "Already a claude skill - no conversion needed"}, nil
+		}
+		c := NewGeminiToClaudeConverter(sourcePath, outputPath)
+		c.Options = opts
+		return c.Plan()
+	case domain.TargetUniversal:
+		return makeUniversal(sourcePath, hasClaude, outputPath, opts)
+	case domain.TargetAuto:
+		return nil, fmt.Errorf("cannot convert to 'Auto' target; must be resolved")
+	default:
+		return nil, fmt.Errorf("unsupported target: %s", target)
+	}
+}
+
+// isClaudePlugin reports whether dir has a Claude Code plugin manifest.
+func isClaudePlugin(dir string) bool {
+	return fileExists(filepath.Join(dir, filepath.FromSlash(pluginManifestFile)))
+}
+
+// planPlugin is PlanConversion for a Claude Code plugin, which only converts
+// to Gemini; universal output adds the extension next to the plugin.
+func planPlugin(sourcePath string, target domain.ConversionTarget, hasGemini bool, outputPath string, opts Options) (*Result, error) {
+	if hasGemini {
+		return &Result{Source: domain.PlatformUniversal, Message: "Already a universal plugin/extension - no conversion needed"}, nil
+	}
+
+	switch target {
+	case domain.TargetClaude:
+		return &Result{Source: domain.PlatformClaudePlugin, Message: "Already a claude plugin - no conversion needed"}, nil
+	case domain.TargetGemini:
+		c := NewPluginToGeminiConverter(sourcePath, outputPath)
+		c.Options = opts
+		return c.Plan()
+	case domain.TargetUniversal:
+		if outputPath == "" {
+			outputPath = sourcePath
+		}
+		plan := NewPlan(outputPath)
+		if filepath.Clean(outputPath) != filepath.Clean(sourcePath) {
+			if err := plan.addTree(sourcePath, ".", false); err != nil {
+				return nil, fmt.Errorf("copy plugin to output directory: %w", err)
+			}
+		}
+		c := NewPluginToGeminiConverter(sourcePath, outputPath)
+		c.Options = opts
+		return c.planInto(plan)
+	case domain.TargetAuto:
+		return nil, fmt.Errorf("cannot convert to 'Auto' target; must be resolved")
+	default:
+		return nil, fmt.Errorf("unsupported target: %s", target)
+	}
+}
+
+// makeUniversal adds the missing platform's files next to the existing ones,
+// like `skill-porter universal`. When outputPath differs from sourcePath the
+// skill is copied there as well so the output directory is itself universal.
+func makeUniversal(sourcePath string, hasClaude bool, outputPath string, opts Options) (*Result, error) {
+	if outputPath == "" {
+		outputPath = sourcePath
+	}
+	plan := NewPlan(outputPath)
+	if filepath.Clean(outputPath) != filepath.Clean(sourcePath) {
+		if err := plan.addTree(sourcePath, ".", false); err != nil {
+			return nil, fmt.Errorf("copy skill to output directory: %w", err)
+		}
+	}
+
+	if hasClaude {
+		c := NewClaudeToGeminiConverter(sourcePath, outputPath)
+		c.Options = opts
+		return c.planInto(plan)
+	}
+	c := NewGeminiToClaudeConverter(sourcePath, outputPath)
+	c.Options = opts
+	return c.planInto(plan)
+}
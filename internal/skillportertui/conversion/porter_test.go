@@ -0,0 +1,83 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestConvert_NoConversionNeeded(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: x\n---\n"), 0644)
+
+	result, err := Convert(dir, domain.TargetClaude, "", Options{})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if result.Message == "" {
+		t.Error("Expected 'no conversion needed' message for Claude -> Claude")
+	}
+
+	os.WriteFile(filepath.Join(dir, "gemini-extension.json"), []byte("{}"), 0644)
+	result, err = Convert(dir, domain.TargetGemini, "", Options{})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if result.Message == "" {
+		t.Error("Expected 'no conversion needed' message for Universal skill")
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	if _, err := Convert(t.TempDir(), domain.TargetGemini, "", Options{}); err == nil {
+		t.Error("Expected error for directory without skill files, got nil")
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: x\n---\n"), 0644)
+	if _, err := Convert(dir, domain.TargetAuto, "", Options{}); err == nil {
+		t.Error("Expected error for unresolved Auto target, got nil")
+	}
+}
+
+func TestConvert_Universal(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.MkdirAll(filepath.Join(src, "scripts"), 0755)
+	os.WriteFile(filepath.Join(src, "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0755)
+
+	// Out of place: the output directory receives both platforms
+	out := filepath.Join(t.TempDir(), "demo")
+	if _, err := Convert(src, domain.TargetUniversal, out, Options{}); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	for _, f := range []string{"SKILL.md", "gemini-extension.json", "GEMINI.md"} {
+		if !fileExists(filepath.Join(out, f)) {
+			t.Errorf("Expected %s in output directory", f)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(out, "scripts", "run.sh")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("Expected executable script to be copied with its mode, got %v (%v)", info, err)
+	}
+	if fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Out-of-place conversion must not modify the source")
+	}
+
+	// In place: Gemini files are added next to SKILL.md
+	if _, err := Convert(src, domain.TargetUniversal, "", Options{}); err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if !fileExists(filepath.Join(src, "gemini-extension.json")) || !fileExists(filepath.Join(src, "SKILL.md")) {
+		t.Error("Expected in-place conversion to keep SKILL.md and add gemini-extension.json")
+	}
+
+	result, err := Convert(src, domain.TargetUniversal, "", Options{})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if result.Message == "" {
+		t.Error("Expected 'no conversion needed' for an already universal skill")
+	}
+}
@@ -0,0 +1,511 @@
+package conversion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+)
+
+// Loss score weights per finding kind, multiplied by the number of items
+// affected. The score is capped at 100.
+var roundtripWeights = map[string]int{
+	"field-lost":          10,
+	"field-changed":       5,
+	"tools-removed":       2,
+	"tools-added":         2,
+	"command-dropped":     15,
+	"command-added":       3,
+	"agent-dropped":       15,
+	"agent-added":         3,
+	"placeholder-changed": 5,
+	"header-added":        2,
+	"footer-added":        2,
+	"body-lost":           1,
+}
+
+var placeholderRe = regexp.MustCompile(`\$ARGUMENTS|\$\d+|\{\{args\}\}`)
+
+// Roundtrip converts the skill at sourcePath to the other platform and back
+// inside a temporary directory, and reports what did not survive. A Universal
+// skill is round-tripped from its Claude side. The source is never modified.
+func Roundtrip(sourcePath string, opts Options) (*domain.RoundtripReport, error) {
+	hasClaude := fileExists(filepath.Join(sourcePath, "SKILL.md"))
+	hasGemini := fileExists(filepath.Join(sourcePath, "gemini-extension.json"))
+	if !hasClaude && isClaudePlugin(sourcePath) {
+		return nil, fmt.Errorf("round trips are not supported for Claude plugins; check each skill under %s/ instead", pluginSkillsDir)
+	}
+	if !hasClaude && !hasGemini {
+		return nil, fmt.Errorf("unable to detect platform type; ensure directory contains valid skill/extension files")
+	}
+
+	tmp, err := os.MkdirTemp("", "skill-porter-roundtrip-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	name := filepath.Base(filepath.Clean(sourcePath))
+	mid := filepath.Join(tmp, "via", name)
+	back := filepath.Join(tmp, "back", name)
+	report := &domain.RoundtripReport{SkillPath: sourcePath}
+
+	if hasClaude {
+		report.From, report.Via = domain.PlatformClaude, domain.PlatformGemini
+		there, home := NewClaudeToGeminiConverter(sourcePath, mid), NewGeminiToClaudeConverter(mid, back)
+		there.Options, home.Options = opts, opts
+		if _, err := there.Convert(); err != nil {
+			return nil, fmt.Errorf("convert to Gemini: %w", err)
+		}
+		if _, err := home.Convert(); err != nil {
+			return nil, fmt.Errorf("convert back to Claude: %w", err)
+		}
+		report.Findings, err = compareClaude(sourcePath, back, opts.catalog())
+	} else {
+		report.From, report.Via = domain.PlatformGemini, domain.PlatformClaude
+		there, home := NewGeminiToClaudeConverter(sourcePath, mid), NewClaudeToGeminiConverter(mid, back)
+		there.Options, home.Options = opts, opts
+		if _, err := there.Convert(); err != nil {
+			return nil, fmt.Errorf("convert to Claude: %w", err)
+		}
+		if _, err := home.Convert(); err != nil {
+			return nil, fmt.Errorf("convert back to Gemini: %w", err)
+		}
+		report.Findings, err = compareGemini(sourcePath, back, opts.catalog())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range report.Findings {
+		report.Score += roundtripWeights[f.Kind] * max(f.Count, 1)
+	}
+	report.Score = min(report.Score, 100)
+	return report, nil
+}
+
+// FormatRoundtrip renders a report for the terminal.
+func FormatRoundtrip(r *domain.RoundtripReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s → %s → %s, loss score %d/100\n", r.SkillPath, r.From, r.Via, r.From, r.Score)
+	if len(r.Findings) == 0 {
+		b.WriteString("  No information lost\n")
+	}
+	for _, f := range r.Findings {
+		fmt.Fprintf(&b, "  - %s\n", f)
+	}
+	return b.String()
+}
+
+func compareClaude(orig, back string, catalog *tools.Catalog) ([]domain.RoundtripFinding, error) {
+	var findings []domain.RoundtripFinding
+
+	origFM, origBody, err := readSkillFile(filepath.Join(orig, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+	backFM, backBody, err := readSkillFile(filepath.Join(back, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	findings = append(findings, compareFields(origFM, backFM, "allowed-tools")...)
+	findings = append(findings, compareTools("allowed-tools", canonicalTools(catalog, domain.PlatformClaude, frontmatterTools(origFM)), canonicalTools(catalog, domain.PlatformClaude, frontmatterTools(backFM)))...)
+	findings = append(findings, compareCommands(
+		readCommands(filepath.Join(orig, ".claude", "commands"), ".md"),
+		readCommands(filepath.Join(back, ".claude", "commands"), ".md"))...)
+	agentFindings, err := compareAgents(orig, back)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, agentFindings...)
+	findings = append(findings, compareBody(origBody, backBody)...)
+	return findings, nil
+}
+
+func compareGemini(orig, back string, catalog *tools.Catalog) ([]domain.RoundtripFinding, error) {
+	var findings []domain.RoundtripFinding
+
+	origManifest, err := readManifestMap(filepath.Join(orig, "gemini-extension.json"))
+	if err != nil {
+		return nil, err
+	}
+	backManifest, err := readManifestMap(filepath.Join(back, "gemini-extension.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	findings = append(findings, compareFields(origManifest, backManifest, "excludeTools")...)
+	findings = append(findings, compareTools("excludeTools",
+		canonicalTools(catalog, domain.PlatformGemini, stringList(origManifest["excludeTools"])),
+		canonicalTools(catalog, domain.PlatformGemini, stringList(backManifest["excludeTools"])))...)
+	findings = append(findings, compareCommands(
+		readCommands(filepath.Join(orig, "commands"), ".toml", geminiAgentsDir),
+		readCommands(filepath.Join(back, "commands"), ".toml", geminiAgentsDir))...)
+	findings = append(findings, compareCommands(
+		readAgentCommands(orig),
+		readAgentCommands(back))...)
+
+	origContext, _ := os.ReadFile(filepath.Join(orig, contextFile(origManifest)))
+	backContext, _ := os.ReadFile(filepath.Join(back, contextFile(backManifest)))
+	findings = append(findings, compareBody(string(origContext), string(backContext))...)
+	return findings, nil
+}
+
+// compareFields reports top-level fields that were dropped or changed,
+// skipping the tool list which is compared separately.
+func compareFields(orig, back map[string]any, toolsKey string) []domain.RoundtripFinding {
+	var findings []domain.RoundtripFinding
+	for _, key := range sortedKeys(orig) {
+		if key == toolsKey {
+			continue
+		}
+		backValue, ok := back[key]
+		switch {
+		case !ok:
+			findings = append(findings, domain.RoundtripFinding{Kind: "field-lost", Subject: key})
+		case !reflect.DeepEqual(normalize(orig[key]), normalize(backValue)):
+			findings = append(findings, domain.RoundtripFinding{
+				Kind:    "field-changed",
+				Subject: key,
+				Detail:  fmt.Sprintf("%s → %s", brief(orig[key]), brief(backValue)),
+			})
+		}
+	}
+	return findings
+}
+
+// compareTools compares the tool lists stored under key. A list that vanished
+// altogether means the restriction itself was lost.
+func compareTools(key string, orig, back []string) []domain.RoundtripFinding {
+	if len(orig) > 0 && len(back) == 0 {
+		return []domain.RoundtripFinding{{Kind: "field-lost", Subject: key, Detail: fmt.Sprintf("%d tool(s) no longer listed", len(orig))}}
+	}
+
+	var findings []domain.RoundtripFinding
+	if removed := missingFrom(orig, back); len(removed) > 0 {
+		findings = append(findings, domain.RoundtripFinding{Kind: "tools-removed", Subject: strings.Join(removed, ", "), Count: len(removed)})
+	}
+	if added := missingFrom(back, orig); len(added) > 0 {
+		findings = append(findings, domain.RoundtripFinding{Kind: "tools-added", Subject: strings.Join(added, ", "), Count: len(added)})
+	}
+	return findings
+}
+
+func compareCommands(orig, back map[string]string) []domain.RoundtripFinding {
+	var findings []domain.RoundtripFinding
+	for _, name := range sortedKeys(orig) {
+		backContent, ok := back[name]
+		if !ok {
+			findings = append(findings, domain.RoundtripFinding{Kind: "command-dropped", Subject: "/" + name})
+			continue
+		}
+		// Only Claude commands have frontmatter; TOML yields no keys
+		for _, key := range missingFrom(frontmatterKeys(orig[name]), frontmatterKeys(backContent)) {
+			findings = append(findings, domain.RoundtripFinding{Kind: "field-lost", Subject: "/" + name + ": " + key})
+		}
+		before := placeholders(orig[name])
+		after := placeholders(backContent)
+		if !reflect.DeepEqual(before, after) {
+			findings = append(findings, domain.RoundtripFinding{
+				Kind:    "placeholder-changed",
+				Subject: "/" + name,
+				Detail:  fmt.Sprintf("%s → %s", orNone(before), orNone(after)),
+			})
+		}
+	}
+	for _, name := range sortedKeys(back) {
+		if _, ok := orig[name]; !ok {
+			findings = append(findings, domain.RoundtripFinding{Kind: "command-added", Subject: "/" + name})
+		}
+	}
+	return findings
+}
+
+// compareAgents reports subagents that were dropped or added, and frontmatter
+// fields of an agent that did not come back.
+func compareAgents(orig, back string) ([]domain.RoundtripFinding, error) {
+	origAgents, _, err := readClaudeAgents(orig, claudeAgentsDir)
+	if err != nil {
+		return nil, err
+	}
+	backAgents, _, err := readClaudeAgents(back, claudeAgentsDir)
+	if err != nil {
+		return nil, err
+	}
+	byName := func(agents []claudeAgent) map[string]claudeAgent {
+		m := map[string]claudeAgent{}
+		for _, a := range agents {
+			m[a.Name] = a
+		}
+		return m
+	}
+	origByName, backByName := byName(origAgents), byName(backAgents)
+
+	var findings []domain.RoundtripFinding
+	for _, name := range sortedKeys(origByName) {
+		backAgent, ok := backByName[name]
+		if !ok {
+			findings = append(findings, domain.RoundtripFinding{Kind: "agent-dropped", Subject: name})
+			continue
+		}
+		for _, f := range origByName[name].Lossy {
+			if !containsYAMLKey(backAgent.Lossy, f.Key) {
+				findings = append(findings, domain.RoundtripFinding{Kind: "field-lost", Subject: "agent " + name + ": " + f.Key})
+			}
+		}
+	}
+	for _, name := range sortedKeys(backByName) {
+		if _, ok := origByName[name]; !ok {
+			findings = append(findings, domain.RoundtripFinding{Kind: "agent-added", Subject: name})
+		}
+	}
+	return findings, nil
+}
+
+// frontmatterKeys returns the keys with a value in a file's frontmatter.
+func frontmatterKeys(content string) []string {
+	fm, err := ParseFrontmatter([]byte(content))
+	if err != nil {
+		return nil
+	}
+	entries, err := fm.Entries()
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, e := range entries {
+		if e.Value != nil {
+			keys = append(keys, e.Key)
+		}
+	}
+	return keys
+}
+
+func containsYAMLKey(entries []yamlEntry, key string) bool {
+	for _, e := range entries {
+		if e.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// compareBody reports text added before the first or after the last line the
+// two bodies share, and original lines that disappeared.
+func compareBody(orig, back string) []domain.RoundtripFinding {
+	ops := diffLines(splitLines(strings.TrimSpace(orig)), splitLines(strings.TrimSpace(back)))
+
+	first, last := -1, -1
+	for i, op := range ops {
+		if op.kind == ' ' && strings.TrimSpace(op.line) != "" {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+
+	var header, footer []string
+	lost := 0
+	for i, op := range ops {
+		if strings.TrimSpace(op.line) == "" {
+			continue
+		}
+		switch {
+		case op.kind == '-':
+			lost++
+		case op.kind == '+' && (first < 0 || i < first):
+			header = append(header, op.line)
+		case op.kind == '+' && i > last:
+			footer = append(footer, op.line)
+		}
+	}
+
+	var findings []domain.RoundtripFinding
+	if len(header) > 0 {
+		findings = append(findings, domain.RoundtripFinding{Kind: "header-added", Subject: brief(header[0]), Detail: fmt.Sprintf("%d line(s)", len(header))})
+	}
+	if len(footer) > 0 {
+		findings = append(findings, domain.RoundtripFinding{Kind: "footer-added", Subject: brief(footer[len(footer)-1]), Detail: fmt.Sprintf("%d line(s)", len(footer))})
+	}
+	if lost > 0 {
+		findings = append(findings, domain.RoundtripFinding{Kind: "body-lost", Subject: fmt.Sprintf("%d line(s)", lost), Count: lost})
+	}
+	return findings
+}
+
+// readSkillFile returns the frontmatter and body of a SKILL.md.
+func readSkillFile(path string) (map[string]any, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	fm := map[string]any{}
+	f, err := ParseFrontmatter(data)
+	if errors.Is(err, ErrNoFrontmatter) {
+		return fm, strings.ReplaceAll(string(data), "\r\n", "\n"), nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid SKILL.md frontmatter: %w", err)
+	}
+	if err := f.Decode(&fm); err != nil {
+		return nil, "", fmt.Errorf("invalid SKILL.md frontmatter: %w", err)
+	}
+	return fm, f.Body(), nil
+}
+
+func readManifestMap(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Base(path), err)
+	}
+	return m, nil
+}
+
+// readCommands maps command names such as git/commit to the contents of the
+// files with ext under dir, leaving out the top-level directories in skip.
+func readCommands(dir, ext string, skip ...string) map[string]string {
+	commands := map[string]string{}
+	names, _ := walkCommands(dir, ext, skip...)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)+ext))
+		if err != nil {
+			continue
+		}
+		commands[name] = string(data)
+	}
+	return commands
+}
+
+// readAgentCommands is readCommands for the Gemini commands that hold Claude
+// subagents, named as they are run: agents:<name>.
+func readAgentCommands(dir string) map[string]string {
+	commands := map[string]string{}
+	for name, content := range readCommands(filepath.Join(dir, "commands", geminiAgentsDir), ".toml") {
+		commands[geminiAgentsDir+":"+name] = content
+	}
+	return commands
+}
+
+func contextFile(manifest map[string]any) string {
+	if name, ok := manifest["contextFileName"].(string); ok && name != "" {
+		return name
+	}
+	return "GEMINI.md"
+}
+
+// frontmatterTools reads allowed-tools as a list or comma-separated string.
+func frontmatterTools(fm map[string]any) []string {
+	if s, ok := fm["allowed-tools"].(string); ok {
+		var tools []string
+		for _, tool := range strings.Split(s, ",") {
+			tools = append(tools, strings.TrimSpace(tool))
+		}
+		return tools
+	}
+	return stringList(fm["allowed-tools"])
+}
+
+// canonicalTools renames tools to their name in catalog on platform, so that
+// an alias or the other platform's name is not reported as a change.
+func canonicalTools(catalog *tools.Catalog, platform string, names []string) []string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = name
+		if tool, ok := catalog.Lookup(platform, name); ok {
+			if n := tool.Name(platform); n != "" {
+				out[i] = n
+			} else {
+				out[i] = tool.Claude + tool.Gemini // Only the other platform's name is set
+			}
+		}
+	}
+	return out
+}
+
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	var out []string
+	for _, item := range list {
+		out = append(out, fmt.Sprint(item))
+	}
+	return out
+}
+
+// placeholders returns the distinct argument placeholders in content.
+func placeholders(content string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, p := range placeholderRe.FindAllString(content, -1) {
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// missingFrom returns the items of a that are not in b.
+func missingFrom(a, b []string) []string {
+	var out []string
+	for _, item := range a {
+		if !containsString(b, item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// normalize makes YAML and JSON values comparable.
+func normalize(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	json.Unmarshal(data, &out)
+	return out
+}
+
+func brief(v any) string {
+	s := fmt.Sprint(v)
+	if data, err := json.Marshal(v); err == nil {
+		if _, isString := v.(string); !isString {
+			s = string(data)
+		}
+	}
+	if r := []rune(s); len(r) > 40 {
+		s = string(r[:40]) + "…"
+	}
+	return s
+}
+
+func orNone(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
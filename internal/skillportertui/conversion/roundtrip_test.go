@@ -0,0 +1,141 @@
+package conversion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func findFinding(findings []domain.RoundtripFinding, kind, subject string) *domain.RoundtripFinding {
+	for i := range findings {
+		if findings[i].Kind == kind && findings[i].Subject == subject {
+			return &findings[i]
+		}
+	}
+	return nil
+}
+
+func TestRoundtrip_Claude(t *testing.T) {
+	src := t.TempDir()
+	skill := `---
+name: reviewer-skill
+description: Reviews code changes for style and correctness issues
+allowed-tools: Read, Write, Edit, Glob, Grep, Bash, Task, WebFetch, WebSearch, TodoWrite, AskUserQuestion, SlashCommand, Skill, NotebookEdit
+subagents:
+  - name: reviewer
+    description: You are a senior code reviewer.
+---
+
+# Reviewer
+
+Review the diff carefully.
+`
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte(skill), 0644)
+	os.MkdirAll(filepath.Join(src, ".claude", "commands"), 0755)
+	os.WriteFile(filepath.Join(src, ".claude", "commands", "review.md"),
+		[]byte("---\ndescription: Review a file\n---\n\nReview $1 against $2.\n"), 0644)
+
+	report, err := Roundtrip(src, Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	if report.From != domain.PlatformClaude || report.Via != domain.PlatformGemini {
+		t.Errorf("Expected Claude via Gemini, got %s via %s", report.From, report.Via)
+	}
+
+	tests := []struct {
+		kind    string
+		subject string
+		detail  string
+	}{
+		{"field-lost", "subagents", ""},
+		// Most tools allowed: excludeTools comes out empty and the whitelist is lost
+		{"field-lost", "allowed-tools", "14 tool(s) no longer listed"},
+		{"command-added", "/reviewer", ""},
+	}
+	for _, tt := range tests {
+		f := findFinding(report.Findings, tt.kind, tt.subject)
+		if f == nil {
+			t.Errorf("Expected %s %s, got %v", tt.kind, tt.subject, report.Findings)
+			continue
+		}
+		if f.Detail != tt.detail {
+			t.Errorf("%s %s: expected detail %q, got %q", tt.kind, tt.subject, tt.detail, f.Detail)
+		}
+	}
+
+	kinds := map[string]bool{}
+	for _, f := range report.Findings {
+		kinds[f.Kind] = true
+	}
+	for _, kind := range []string{"header-added", "footer-added"} {
+		if !kinds[kind] {
+			t.Errorf("Expected a %s finding, got %v", kind, report.Findings)
+		}
+	}
+	// Positional arguments survive through the argument preamble
+	if f := findFinding(report.Findings, "placeholder-changed", "/review"); f != nil {
+		t.Errorf("Expected $1 and $2 to survive, got %v", f)
+	}
+	if report.Score < 20 || report.Score > 100 {
+		t.Errorf("Expected a substantial loss score, got %d", report.Score)
+	}
+
+	// The source is never touched
+	if fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Expected round trip to leave the source untouched")
+	}
+}
+
+func TestRoundtrip_Gemini(t *testing.T) {
+	src := t.TempDir()
+	manifest := `{
+  "name": "api-ext",
+  "version": "2.0.0",
+  "description": "Talks to the API",
+  "contextFileName": "GEMINI.md",
+  "excludeTools": ["Bash"],
+  "homepage": "https://example.com"
+}`
+	os.WriteFile(filepath.Join(src, "gemini-extension.json"), []byte(manifest), 0644)
+	os.WriteFile(filepath.Join(src, "GEMINI.md"), []byte("# API\n\nCall the API.\n"), 0644)
+
+	report, err := Roundtrip(src, Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	if report.From != domain.PlatformGemini {
+		t.Errorf("Expected Gemini origin, got %s", report.From)
+	}
+	if findFinding(report.Findings, "field-lost", "homepage") == nil {
+		t.Errorf("Expected homepage to be reported lost, got %v", report.Findings)
+	}
+	// A short blacklist becomes a long whitelist, which cannot be turned
+	// back into a blacklist
+	if findFinding(report.Findings, "field-lost", "excludeTools") == nil {
+		t.Errorf("Expected excludeTools to be reported lost, got %v", report.Findings)
+	}
+	if findFinding(report.Findings, "field-changed", "version") != nil {
+		t.Errorf("Expected version to survive, got %v", report.Findings)
+	}
+}
+
+func TestRoundtrip_Lossless(t *testing.T) {
+	report, err := Roundtrip(filepath.Join(fixturesDir, "code-formatter-converted"), Options{})
+	if err != nil {
+		t.Fatalf("Roundtrip failed: %v", err)
+	}
+	for _, f := range report.Findings {
+		if f.Kind != "header-added" && f.Kind != "footer-added" {
+			t.Errorf("Expected only header/footer noise for the fixture, got %v", f)
+		}
+	}
+}
+
+func TestRoundtrip_NotASkill(t *testing.T) {
+	if _, err := Roundtrip(t.TempDir(), Options{}); err == nil {
+		t.Error("Expected error for directory without skill files, got nil")
+	}
+}
@@ -0,0 +1,110 @@
+package conversion
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/settings"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/tools"
+	"gopkg.in/yaml.v3"
+)
+
+const sharedReferenceContent = "# Technical Reference\n" +
+	"\n" +
+	"## Architecture\n" +
+	"For detailed extension architecture, please refer to `docs/GEMINI_ARCHITECTURE.md` (in Gemini extensions) or the `SKILL.md` structure (in Claude Skills).\n" +
+	"\n" +
+	"## Platform Differences\n" +
+	"- **Commands:**\n" +
+	"  - Gemini uses `commands/*.toml`\n" +
+	"  - Claude uses `.claude/commands/*.md`\n" +
+	"- **Agents:**\n" +
+	"  - Gemini \"Agents\" are implemented as Custom Commands.\n" +
+	"  - Claude \"Subagents\" are defined in `SKILL.md` frontmatter.\n"
+
+const sharedExamplesContent = "# Usage Examples\n\nComprehensive usage examples and tutorials.\n"
+
+const converterRepoURL = "https://github.com/jduncan-rva/skill-porter"
+
+// claudeExcludeToolsKey is the gemini-extension.json key, ignored by Gemini,
+// listing the Claude-only tools a skill's allowed-tools left out.
+const claudeExcludeToolsKey = "claudeExcludeTools"
+
+// Options configures a conversion. The zero value uses the built-in tool
+// catalog and settings rules, and converts a plugin into one extension.
+type Options struct {
+	Tools        *tools.Catalog  // Translates tool names between the platforms
+	Settings     *settings.Rules // Describes the environment variables of MCP servers
+	SplitPlugins bool            // One Gemini extension per plugin skill
+}
+
+// catalog returns the tool catalog to convert with.
+func (o Options) catalog() *tools.Catalog {
+	if o.Tools == nil {
+		return tools.Default()
+	}
+	return o.Tools
+}
+
+// rules returns the settings rules to convert with.
+func (o Options) rules() *settings.Rules {
+	if o.Settings == nil {
+		return settings.Default()
+	}
+	return o.Settings
+}
+
+// toolKey identifies a catalog tool regardless of the name it was found by.
+func toolKey(t tools.Tool) string {
+	return t.Claude + "\x00" + t.Gemini
+}
+
+// ensureSharedStructure schedules shared/ with placeholder documents unless
+// the directory already exists.
+func ensureSharedStructure(plan *Plan) {
+	if plan.Exists("shared") {
+		return
+	}
+	plan.Add("shared/reference.md", []byte(sharedReferenceContent), 0644)
+	plan.Add("shared/examples.md", []byte(sharedExamplesContent), 0644)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir()
+}
+
+// claudeCommandKeys are the frontmatter keys Claude Code itself understands
+// in .claude/commands/*.md. Any other key is carried over to Gemini as is.
+var claudeCommandKeys = []string{"description", "allowed-tools", "argument-hint", "model", "disable-model-invocation"}
+
+// yamlEntry is one top-level key of a YAML mapping.
+type yamlEntry struct {
+	Key   string
+	Value any
+}
+
+// marshalYAMLEntries renders entries as a YAML mapping in order.
+func marshalYAMLEntries(entries []yamlEntry) ([]byte, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for _, e := range entries {
+		var value yaml.Node
+		if err := value.Encode(e.Value); err != nil {
+			return nil, fmt.Errorf("encode %s: %w", e.Key, err)
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: e.Key}, &value)
+	}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(mapping); err != nil {
+		return nil, err
+	}
+	enc.Close()
+	return b.Bytes(), nil
+}
@@ -0,0 +1,176 @@
+package conversion
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// Filesystem operations used while applying a plan, replaced in tests to
+// inject failures.
+var (
+	writeFile = os.WriteFile
+	rename    = os.Rename
+)
+
+// stagePrefix names the temporary directories created inside the output
+// directory while a plan is applied.
+const stagePrefix = domain.StageDirPrefix
+
+// Apply writes every planned file into the output directory. See ApplyContext.
+func (p *Plan) Apply() error {
+	return p.ApplyContext(context.Background())
+}
+
+// ApplyContext writes the plan into a staging directory inside the output
+// directory and then moves each file into place. If writing fails, or ctx is
+// done before the commit starts, no planned file is touched; if moving a file
+// fails, every file already moved is rolled back. Either way no partial
+// conversion is left behind. Staging directories left by an earlier apply
+// that crashed are removed first.
+func (p *Plan) ApplyContext(ctx context.Context) (err error) {
+	if p.err != nil {
+		return p.err
+	}
+	if len(p.files) == 0 {
+		return nil
+	}
+
+	out := filepath.Clean(p.OutputDir)
+	log := &commitLog{backups: make(map[string]string)}
+	if err := log.mkdirAll(out); err != nil {
+		return err
+	}
+	if err := removeStages(out); err != nil {
+		return errors.Join(err, log.rollback())
+	}
+	stage, err := os.MkdirTemp(out, stagePrefix)
+	if err != nil {
+		return errors.Join(err, log.rollback())
+	}
+	defer os.RemoveAll(stage)
+	// Runs before the staging directory, which holds the backups, is removed
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, log.rollback())
+		}
+	}()
+
+	newDir := filepath.Join(stage, "new")
+	for _, f := range p.files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		dest := filepath.Join(newDir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := writeFile(dest, f.Content, f.Mode); err != nil {
+			return err
+		}
+		// WriteFile's mode is filtered by umask
+		if err := os.Chmod(dest, f.Mode); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return p.commit(log, newDir, filepath.Join(stage, "old"))
+}
+
+// removeStages removes the staging directories in dir.
+func removeStages(dir string) error {
+	stages, err := filepath.Glob(filepath.Join(dir, stagePrefix+"*"))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, stage := range stages {
+		if err := os.RemoveAll(stage); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// commitLog records what commit changed so that it can be undone.
+type commitLog struct {
+	createdDirs []string // Outermost directories that did not exist before
+	backups     map[string]string
+	moved       []string
+}
+
+// commit moves the staged files from newDir into the output directory,
+// keeping any files they replace in oldDir until every move has succeeded.
+func (p *Plan) commit(log *commitLog, newDir, oldDir string) error {
+	for _, f := range p.files {
+		dest := p.Abs(f.Path)
+		if err := log.mkdirAll(filepath.Dir(dest)); err != nil {
+			return err
+		}
+
+		if _, err := os.Lstat(dest); err == nil {
+			backup := filepath.Join(oldDir, filepath.FromSlash(f.Path))
+			if err := os.MkdirAll(filepath.Dir(backup), 0755); err != nil {
+				return err
+			}
+			if err := rename(dest, backup); err != nil {
+				return err
+			}
+			log.backups[dest] = backup
+		}
+
+		if err := rename(filepath.Join(newDir, filepath.FromSlash(f.Path)), dest); err != nil {
+			return err
+		}
+		log.moved = append(log.moved, dest)
+	}
+	return nil
+}
+
+// mkdirAll creates dir and records the outermost directory it had to create.
+func (l *commitLog) mkdirAll(dir string) error {
+	missing := ""
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = d
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if missing != "" {
+		l.createdDirs = append(l.createdDirs, missing)
+	}
+	return nil
+}
+
+// rollback undoes a partial commit and returns any errors hit while restoring.
+func (l *commitLog) rollback() error {
+	var errs []error
+	for i := len(l.moved) - 1; i >= 0; i-- {
+		if err := os.Remove(l.moved[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for dest, backup := range l.backups {
+		if err := os.Rename(backup, dest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(l.createdDirs) - 1; i >= 0; i-- {
+		if err := os.RemoveAll(l.createdDirs[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
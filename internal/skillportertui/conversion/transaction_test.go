@@ -0,0 +1,184 @@
+package conversion
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// snapshot returns every file under dir with its content.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, _ := os.ReadFile(path)
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	return files
+}
+
+// transactionFixture returns a skill directory with one existing file and a
+// plan that overwrites it and creates files in new directories.
+func transactionFixture(t *testing.T) (string, *Plan) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "skill")
+	os.MkdirAll(dir, 0755)
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("original\n"), 0644)
+
+	plan := NewPlan(dir)
+	plan.Add("SKILL.md", []byte("converted\n"), 0644)
+	plan.Add("commands/a.toml", []byte("a\n"), 0644)
+	plan.Add("docs/GEMINI_ARCHITECTURE.md", []byte("docs\n"), 0644)
+	return dir, plan
+}
+
+func assertUntouched(t *testing.T, dir string) {
+	t.Helper()
+	got := snapshot(t, dir)
+	if len(got) != 1 || got["SKILL.md"] != "original\n" {
+		t.Errorf("Expected only the original SKILL.md, got %v", got)
+	}
+	for _, sub := range []string{"commands", "docs"} {
+		if _, err := os.Stat(filepath.Join(dir, sub)); err == nil {
+			t.Errorf("Expected %s/ to be removed", sub)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), stagePrefix) {
+			t.Errorf("Expected staging directory %s to be cleaned up", e.Name())
+		}
+	}
+}
+
+func TestApply_Commits(t *testing.T) {
+	dir, plan := transactionFixture(t)
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	got := snapshot(t, dir)
+	want := map[string]string{
+		"SKILL.md":                    "converted\n",
+		"commands/a.toml":             "a\n",
+		"docs/GEMINI_ARCHITECTURE.md": "docs\n",
+	}
+	for path, content := range want {
+		if got[path] != content {
+			t.Errorf("%s: expected %q, got %q", path, content, got[path])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("Expected %d files, got %v", len(want), got)
+	}
+}
+
+func TestApply_RemovesStaleStages(t *testing.T) {
+	dir, plan := transactionFixture(t)
+	stale := filepath.Join(dir, stagePrefix+"123", "new")
+	os.MkdirAll(stale, 0755)
+	os.WriteFile(filepath.Join(stale, "SKILL.md"), []byte("stale\n"), 0644)
+
+	if err := plan.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(stale)); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale staging directory to be removed, got %v", err)
+	}
+}
+
+func TestApply_WriteFailure(t *testing.T) {
+	dir, plan := transactionFixture(t)
+
+	calls := 0
+	writeFile = func(name string, data []byte, perm fs.FileMode) error {
+		calls++
+		if calls == 2 {
+			return errors.New("disk full")
+		}
+		return os.WriteFile(name, data, perm)
+	}
+	defer func() { writeFile = os.WriteFile }()
+
+	if err := plan.Apply(); err == nil {
+		t.Fatal("Expected Apply to fail")
+	}
+	assertUntouched(t, dir)
+}
+
+func TestApply_RenameFailureRollsBack(t *testing.T) {
+	// Fail at each rename in turn: backing up SKILL.md, moving SKILL.md in,
+	// moving commands/a.toml in, and moving the docs in
+	defer func() { rename = os.Rename }()
+	for failAt := 1; failAt <= 4; failAt++ {
+		dir, plan := transactionFixture(t)
+
+		calls := 0
+		rename = func(oldpath, newpath string) error {
+			calls++
+			if calls == failAt {
+				return errors.New("rename failed")
+			}
+			return os.Rename(oldpath, newpath)
+		}
+
+		if err := plan.Apply(); err == nil {
+			t.Errorf("failAt=%d: expected Apply to fail", failAt)
+		}
+		assertUntouched(t, dir)
+	}
+}
+
+func TestApply_CancelledContext(t *testing.T) {
+	dir, plan := transactionFixture(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writes := 0
+	writeFile = func(name string, data []byte, perm fs.FileMode) error {
+		writes++
+		if writes == 2 {
+			cancel() // Times out partway through staging
+		}
+		return os.WriteFile(name, data, perm)
+	}
+	defer func() { writeFile = os.WriteFile }()
+
+	err := plan.ApplyContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	assertUntouched(t, dir)
+}
+
+func TestApply_NewOutputDirRemovedOnFailure(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out", "skill")
+	plan := NewPlan(out)
+	plan.Add("a.txt", []byte("a"), 0644)
+	plan.Add("b.txt", []byte("b"), 0644)
+
+	calls := 0
+	rename = func(oldpath, newpath string) error {
+		calls++
+		if calls == 2 {
+			return errors.New("rename failed")
+		}
+		return os.Rename(oldpath, newpath)
+	}
+	defer func() { rename = os.Rename }()
+
+	if err := plan.Apply(); err == nil {
+		t.Fatal("Expected Apply to fail")
+	}
+	if _, err := os.Stat(filepath.Dir(out)); !os.IsNotExist(err) {
+		t.Errorf("Expected new directories above %s to be removed, got %v", out, err)
+	}
+}
@@ -0,0 +1,250 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+// Detect analyses dir and reports its platform, marker files, confidence and
+// metadata. It is a port of PlatformDetector in src/analyzers/detector.js,
+// except that a skill whose entry or manifest files are broken is reported
+// as domain.PlatformInvalid rather than as convertible.
+func Detect(dir string) (domain.Detection, error) {
+	det := domain.Detection{
+		Platform:   domain.PlatformInvalid,
+		Confidence: "low",
+	}
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return det, fmt.Errorf("directory not found: %s", dir)
+	}
+
+	var claudeMeta, geminiMeta, marketplaceMeta, pluginMeta map[string]any
+	hasClaude, hasGemini, hasPlugin := false, false, false
+
+	// Claude files
+	if data, err := os.ReadFile(filepath.Join(dir, "SKILL.md")); err == nil {
+		hasClaude = true
+		file := domain.DetectedFile{File: "SKILL.md", Type: "entry", Valid: true}
+		if fm, err := conversion.ParseFrontmatter(data); errors.Is(err, conversion.ErrNoFrontmatter) {
+			file.Valid = false
+			file.Issue = "Missing or invalid YAML frontmatter"
+		} else if err != nil || fm.Decode(&claudeMeta) != nil || claudeMeta == nil {
+			file.Valid = false
+			file.Issue = "Invalid YAML frontmatter"
+		}
+		det.ClaudeFiles = append(det.ClaudeFiles, file)
+	}
+
+	// marketplace.json is optional and the converters ignore a broken one, so
+	// it is only reported
+	if data, err := os.ReadFile(filepath.Join(dir, ".claude-plugin", "marketplace.json")); err == nil {
+		if err := json.Unmarshal(data, &marketplaceMeta); err != nil {
+			marketplaceMeta = nil
+			det.Diagnostics = append(det.Diagnostics, domain.Diagnostic{
+				RuleID:   "detect/marketplace-json",
+				Severity: domain.SeverityWarning,
+				File:     ".claude-plugin/marketplace.json",
+				Message:  "Invalid JSON; the file is ignored",
+			})
+		} else {
+			det.ClaudeFiles = append(det.ClaudeFiles, domain.DetectedFile{File: ".claude-plugin/marketplace.json", Type: "manifest", Valid: true})
+		}
+	}
+
+	det.ClaudeFiles = append(det.ClaudeFiles, detectAgents(dir, ".claude/agents")...)
+
+	// Plugin layout: .claude-plugin/plugin.json with skills/<name>/SKILL.md
+	if data, err := os.ReadFile(filepath.Join(dir, ".claude-plugin", "plugin.json")); err == nil {
+		hasPlugin = true
+		file := domain.DetectedFile{File: ".claude-plugin/plugin.json", Type: "manifest", Valid: true}
+		if err := json.Unmarshal(data, &pluginMeta); err != nil {
+			file.Valid = false
+			file.Issue = "Invalid JSON"
+		} else if firstString(pluginMeta["name"]) == "" {
+			file.Valid = false
+			file.Issue = "Missing name"
+		}
+		det.ClaudeFiles = append(det.ClaudeFiles, file)
+		det.ClaudeFiles = append(det.ClaudeFiles, detectPluginSkills(dir)...)
+		det.ClaudeFiles = append(det.ClaudeFiles, detectAgents(dir, "agents")...)
+		if dirExists(filepath.Join(dir, "commands")) {
+			det.ClaudeFiles = append(det.ClaudeFiles, domain.DetectedFile{File: "commands/", Type: "directory", Valid: true})
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ".mcp.json")); err == nil {
+		file := domain.DetectedFile{File: ".mcp.json", Type: "mcp", Valid: true}
+		if !json.Valid(data) {
+			file.Valid = false
+			file.Issue = "Invalid JSON"
+		}
+		det.ClaudeFiles = append(det.ClaudeFiles, file)
+	}
+
+	// Gemini files
+	if data, err := os.ReadFile(filepath.Join(dir, "gemini-extension.json")); err == nil {
+		hasGemini = true
+		file := domain.DetectedFile{File: "gemini-extension.json", Type: "manifest", Valid: true}
+		if err := json.Unmarshal(data, &geminiMeta); err != nil {
+			file.Valid = false
+			file.Issue = "Invalid JSON"
+		}
+		det.GeminiFiles = append(det.GeminiFiles, file)
+	}
+
+	if fileExists(filepath.Join(dir, "GEMINI.md")) {
+		det.GeminiFiles = append(det.GeminiFiles, domain.DetectedFile{File: "GEMINI.md", Type: "context", Valid: true})
+	}
+
+	// Shared files
+	if fileExists(filepath.Join(dir, "package.json")) {
+		det.SharedFiles = append(det.SharedFiles, domain.DetectedFile{File: "package.json", Type: "dependency", Valid: true})
+	}
+	for _, sub := range []string{"shared", "mcp-server"} {
+		if dirExists(filepath.Join(dir, sub)) {
+			det.SharedFiles = append(det.SharedFiles, domain.DetectedFile{File: sub + "/", Type: "directory", Valid: true})
+		}
+	}
+
+	if !hasClaude && hasPlugin {
+		// The plugin manifest stands in for SKILL.md frontmatter
+		claudeMeta = pluginMeta
+	}
+	det.Metadata = extractMetadata(claudeMeta, geminiMeta, marketplaceMeta)
+	if det.Metadata.Version == "" && hasPlugin {
+		det.Metadata.Version = firstString(pluginMeta["version"])
+	}
+
+	if len(det.Issues()) > 0 {
+		return det, nil
+	}
+
+	switch {
+	case (hasClaude || hasPlugin) && hasGemini:
+		det.Platform = domain.PlatformUniversal
+	case hasClaude:
+		det.Platform = domain.PlatformClaude
+	case hasPlugin:
+		det.Platform = domain.PlatformClaudePlugin
+	case hasGemini:
+		det.Platform = domain.PlatformGemini
+	default:
+		return det, nil
+	}
+	det.Confidence = "high"
+
+	return det, nil
+}
+
+// AutoTarget is the target an Auto conversion of a skill on platform
+// resolves to: the other platform, or Gemini when that is unclear.
+func AutoTarget(platform string) domain.ConversionTarget {
+	if platform == domain.PlatformGemini {
+		return domain.TargetClaude
+	}
+	return domain.TargetGemini
+}
+
+// detectAgents reports the subagent definitions in agentsDir, ".claude/agents"
+// for a skill or "agents" for a plugin. An agent without frontmatter cannot
+// be converted, so it is reported as invalid like a broken SKILL.md.
+func detectAgents(dir, agentsDir string) []domain.DetectedFile {
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(agentsDir)))
+	if err != nil {
+		return nil
+	}
+
+	var files []domain.DetectedFile
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		file := domain.DetectedFile{File: agentsDir + "/" + entry.Name(), Type: "agent", Valid: true}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(agentsDir), entry.Name()))
+		if err != nil {
+			continue
+		}
+		var meta map[string]any
+		if fm, err := conversion.ParseFrontmatter(data); errors.Is(err, conversion.ErrNoFrontmatter) {
+			file.Valid = false
+			file.Issue = "Missing or invalid YAML frontmatter"
+		} else if err != nil || fm.Decode(&meta) != nil {
+			file.Valid = false
+			file.Issue = "Invalid YAML frontmatter"
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// detectPluginSkills reports the skills/<name>/SKILL.md files of a plugin,
+// validating their frontmatter like a top-level SKILL.md.
+func detectPluginSkills(dir string) []domain.DetectedFile {
+	entries, err := os.ReadDir(filepath.Join(dir, "skills"))
+	if err != nil {
+		return nil
+	}
+
+	var files []domain.DetectedFile
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "skills", entry.Name(), "SKILL.md"))
+		if err != nil {
+			continue
+		}
+		file := domain.DetectedFile{File: "skills/" + entry.Name() + "/SKILL.md", Type: "skill", Valid: true}
+		var meta map[string]any
+		if fm, err := conversion.ParseFrontmatter(data); errors.Is(err, conversion.ErrNoFrontmatter) {
+			file.Valid = false
+			file.Issue = "Missing or invalid YAML frontmatter"
+		} else if err != nil || fm.Decode(&meta) != nil {
+			file.Valid = false
+			file.Issue = "Invalid YAML frontmatter"
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// extractMetadata prefers SKILL.md frontmatter for name and description and
+// gemini-extension.json for the version, falling back to the other sources.
+func extractMetadata(claude, gemini, marketplace map[string]any) domain.SkillMetadata {
+	meta := domain.SkillMetadata{
+		Name:        firstString(claude["name"], gemini["name"]),
+		Description: firstString(claude["description"], gemini["description"]),
+		Version:     firstString(gemini["version"]),
+	}
+	if metadata, ok := marketplace["metadata"].(map[string]any); ok && meta.Version == "" {
+		meta.Version = firstString(metadata["version"])
+	}
+	return meta
+}
+
+func firstString(values ...any) string {
+	for _, v := range values {
+		switch t := v.(type) {
+		case nil:
+			continue
+		case string:
+			if t != "" {
+				return t
+			}
+		default:
+			return fmt.Sprint(t)
+		}
+	}
+	return ""
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,207 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestDetect_Universal(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n\nBody\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "gemini-extension.json"), []byte(`{"name": "demo", "version": "2.0.0"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "GEMINI.md"), []byte("# Demo\n"), 0644)
+	os.Mkdir(filepath.Join(dir, "shared"), 0755)
+	os.Mkdir(filepath.Join(dir, "mcp-server"), 0755)
+
+	det, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	if det.Platform != domain.PlatformUniversal || det.Confidence != "high" {
+		t.Errorf("Expected Universal/high, got %s/%s", det.Platform, det.Confidence)
+	}
+	if len(det.ClaudeFiles) != 1 || len(det.GeminiFiles) != 2 || len(det.SharedFiles) != 2 {
+		t.Errorf("Unexpected file lists: claude=%v gemini=%v shared=%v", det.ClaudeFiles, det.GeminiFiles, det.SharedFiles)
+	}
+
+	want := domain.SkillMetadata{Name: "demo", Description: "Demo skill", Version: "2.0.0"}
+	if det.Metadata != want {
+		t.Errorf("Expected metadata %+v, got %+v", want, det.Metadata)
+	}
+}
+
+func TestDetect_ClaudeVersionFromMarketplace(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude-plugin", "marketplace.json"), []byte(`{"metadata": {"version": "1.2.3"}}`), 0644)
+
+	det, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if det.Platform != domain.PlatformClaude {
+		t.Errorf("Expected Claude, got %s", det.Platform)
+	}
+	if det.Metadata.Version != "1.2.3" {
+		t.Errorf("Expected version from marketplace.json, got %q", det.Metadata.Version)
+	}
+}
+
+func TestDetect_InvalidMarketplaceIsOnlyReported(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude-plugin", "marketplace.json"), []byte("{not json"), 0644)
+
+	det, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if det.Platform != domain.PlatformClaude || det.Confidence != "high" {
+		t.Errorf("Expected Claude/high, got %s/%s", det.Platform, det.Confidence)
+	}
+	if len(det.Diagnostics) != 1 || det.Diagnostics[0].Severity != domain.SeverityWarning || det.Diagnostics[0].File != ".claude-plugin/marketplace.json" {
+		t.Errorf("Expected a warning about marketplace.json, got %v", det.Diagnostics)
+	}
+}
+
+func TestDetect_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		body  string
+		issue string
+	}{
+		{"No frontmatter", "SKILL.md", "# Title\n", "Missing or invalid YAML frontmatter"},
+		{"Bad YAML", "SKILL.md", "---\nname: [unclosed\n---\n", "Invalid YAML frontmatter"},
+		{"Bad JSON", "gemini-extension.json", "{not json", "Invalid JSON"},
+		{"Agent without frontmatter", ".claude/agents/reviewer.md", "You review code.\n", "Missing or invalid YAML frontmatter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.MkdirAll(filepath.Dir(filepath.Join(dir, tt.file)), 0755)
+			os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.body), 0644)
+
+			det, err := Detect(dir)
+			if err != nil {
+				t.Fatalf("Detect failed: %v", err)
+			}
+			if det.Platform != domain.PlatformInvalid || det.Confidence != "low" {
+				t.Errorf("Expected %s/low, got %s/%s", domain.PlatformInvalid, det.Platform, det.Confidence)
+			}
+			issues := det.Issues()
+			if len(issues) != 1 || issues[0] != tt.file+": "+tt.issue {
+				t.Errorf("Unexpected issues: %v", issues)
+			}
+		})
+	}
+}
+
+func TestDetect_Agents(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, ".claude", "agents"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude", "agents", "reviewer.md"), []byte("---\nname: reviewer\ndescription: Reviews code\ntools: Read, Grep\n---\nYou review code.\n"), 0644)
+
+	det, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if det.Platform != domain.PlatformClaude {
+		t.Errorf("Expected %s, got %s", domain.PlatformClaude, det.Platform)
+	}
+	found := false
+	for _, f := range det.ClaudeFiles {
+		if f.File == ".claude/agents/reviewer.md" && f.Type == "agent" && f.Valid {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the agent to be listed as a Claude file, got %v", det.ClaudeFiles)
+	}
+}
+
+func TestDetect_ClaudePlugin(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, ".claude-plugin"), 0755)
+	os.MkdirAll(filepath.Join(dir, "skills", "pdf"), 0755)
+	os.MkdirAll(filepath.Join(dir, "agents"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude-plugin", "plugin.json"), []byte(`{"name": "docs", "description": "Document tools", "version": "0.3.0"}`), 0644)
+	os.WriteFile(filepath.Join(dir, ".mcp.json"), []byte(`{"mcpServers": {}}`), 0644)
+	os.WriteFile(filepath.Join(dir, "skills", "pdf", "SKILL.md"), []byte("---\nname: pdf\ndescription: PDF forms\n---\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "agents", "reviewer.md"), []byte("---\nname: reviewer\ndescription: Reviews\n---\n"), 0644)
+
+	det, err := Detect(dir)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if det.Platform != domain.PlatformClaudePlugin || det.Confidence != "high" {
+		t.Errorf("Expected %s/high, got %s/%s", domain.PlatformClaudePlugin, det.Platform, det.Confidence)
+	}
+	want := domain.SkillMetadata{Name: "docs", Description: "Document tools", Version: "0.3.0"}
+	if det.Metadata != want {
+		t.Errorf("Expected metadata %+v, got %+v", want, det.Metadata)
+	}
+
+	types := map[string]string{}
+	for _, f := range det.ClaudeFiles {
+		types[f.File] = f.Type
+	}
+	for file, typ := range map[string]string{
+		".claude-plugin/plugin.json": "manifest",
+		"skills/pdf/SKILL.md":        "skill",
+		"agents/reviewer.md":         "agent",
+		".mcp.json":                  "mcp",
+	} {
+		if types[file] != typ {
+			t.Errorf("Expected %s to be detected as %s, got %v", file, typ, det.ClaudeFiles)
+		}
+	}
+
+	// A plugin that already has an extension manifest is universal
+	os.WriteFile(filepath.Join(dir, "gemini-extension.json"), []byte(`{"name": "docs", "version": "0.3.0"}`), 0644)
+	if det, _ := Detect(dir); det.Platform != domain.PlatformUniversal {
+		t.Errorf("Expected Universal, got %s", det.Platform)
+	}
+}
+
+func TestDetect_ClaudePluginWithoutName(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude-plugin", "plugin.json"), []byte(`{"version": "1.0.0"}`), 0644)
+
+	det, _ := Detect(dir)
+	if det.Platform != domain.PlatformInvalid {
+		t.Errorf("Expected %s, got %s", domain.PlatformInvalid, det.Platform)
+	}
+}
+
+func TestAutoTarget(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     domain.ConversionTarget
+	}{
+		{domain.PlatformClaude, domain.TargetGemini},
+		{domain.PlatformClaudePlugin, domain.TargetGemini},
+		{domain.PlatformGemini, domain.TargetClaude},
+		{domain.PlatformUniversal, domain.TargetGemini},
+	}
+	for _, tt := range tests {
+		if got := AutoTarget(tt.platform); got != tt.want {
+			t.Errorf("AutoTarget(%s): expected %s, got %s", tt.platform, tt.want, got)
+		}
+	}
+}
+
+func TestDetect_MissingDir(t *testing.T) {
+	if _, err := Detect(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing directory, got nil")
+	}
+}
@@ -10,7 +10,8 @@ import (
 )
 
 // DiscoverSkills walks the directory tree rooted at root and returns a list of discovered skills.
-// It identifies skills by the presence of SKILL.md (Claude) or gemini-extension.json (Gemini).
+// It identifies skills by the presence of SKILL.md (Claude), .claude-plugin/plugin.json
+// (Claude plugin) or gemini-extension.json (Gemini) and runs Detect on each one to classify it.
 func DiscoverSkills(root string, recursive bool) ([]domain.SkillDir, error) {
 	var skills []domain.SkillDir
 	seen := make(map[string]bool)
@@ -28,6 +29,11 @@ func DiscoverSkills(root string, recursive bool) ([]domain.SkillDir, error) {
 			return nil
 		}
 
+		// Staging directories of an interrupted conversion hold copies of a skill
+		if path != root && strings.HasPrefix(d.Name(), domain.StageDirPrefix) {
+			return filepath.SkipDir
+		}
+
 		// Handle recursion depth
 		if !recursive {
 			rel, err := filepath.Rel(root, path)
@@ -41,28 +47,26 @@ func DiscoverSkills(root string, recursive bool) ([]domain.SkillDir, error) {
 
 		// Check for skill markers
 		isClaude := fileExists(filepath.Join(path, "SKILL.md"))
+		isPlugin := fileExists(filepath.Join(path, ".claude-plugin", "plugin.json"))
 		isGemini := fileExists(filepath.Join(path, "gemini-extension.json"))
-		
-		platform := ""
-		if isClaude && isGemini {
-			platform = "Universal"
-		} else if isClaude {
-			platform = "Claude"
-		} else if isGemini {
-			platform = "Gemini"
-		}
 
-		if platform != "" {
+		if isClaude || isPlugin || isGemini {
 			// Deduplicate (unlikely needed with WalkDir logic but safe)
 			if seen[path] {
 				return filepath.SkipDir
 			}
 			seen[path] = true
 
+			detection, err := Detect(path)
+			if err != nil {
+				return filepath.SkipDir
+			}
+
 			skills = append(skills, domain.SkillDir{
 				Name:            filepath.Base(path),
 				Path:            path,
-				CurrentPlatform: platform,
+				CurrentPlatform: detection.Platform,
+				Detection:       &detection,
 				Status:          domain.StatusPending,
 				Target:          domain.TargetAuto, // Default
 			})
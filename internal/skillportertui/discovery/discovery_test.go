@@ -4,6 +4,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+const (
+	validSkillMD    = "---\nname: demo\ndescription: Demo skill\n---\n"
+	validManifestJS = `{"name": "demo", "version": "1.0.0"}`
 )
 
 func TestDiscoverSkills(t *testing.T) {
@@ -13,25 +20,25 @@ func TestDiscoverSkills(t *testing.T) {
 	// 1. Claude Skill
 	claudeDir := filepath.Join(tmpDir, "claude-skill")
 	os.Mkdir(claudeDir, 0755)
-	os.WriteFile(filepath.Join(claudeDir, "SKILL.md"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(claudeDir, "SKILL.md"), []byte(validSkillMD), 0644)
 
 	// 2. Gemini Skill
 	geminiDir := filepath.Join(tmpDir, "gemini-skill")
 	os.Mkdir(geminiDir, 0755)
-	os.WriteFile(filepath.Join(geminiDir, "gemini-extension.json"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(geminiDir, "gemini-extension.json"), []byte(validManifestJS), 0644)
 
 	// 3. Universal Skill
 	univDir := filepath.Join(tmpDir, "univ-skill")
 	os.Mkdir(univDir, 0755)
-	os.WriteFile(filepath.Join(univDir, "SKILL.md"), []byte{}, 0644)
-	os.WriteFile(filepath.Join(univDir, "gemini-extension.json"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(univDir, "SKILL.md"), []byte(validSkillMD), 0644)
+	os.WriteFile(filepath.Join(univDir, "gemini-extension.json"), []byte(validManifestJS), 0644)
 
 	// 4. Nested Skill
 	nestedRoot := filepath.Join(tmpDir, "category")
 	os.Mkdir(nestedRoot, 0755)
 	nestedSkill := filepath.Join(nestedRoot, "nested-skill")
 	os.Mkdir(nestedSkill, 0755)
-	os.WriteFile(filepath.Join(nestedSkill, "SKILL.md"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(nestedSkill, "SKILL.md"), []byte(validSkillMD), 0644)
 
 	// 5. Empty Dir (should be ignored)
 	os.Mkdir(filepath.Join(tmpDir, "empty"), 0755)
@@ -71,3 +78,44 @@ func TestDiscoverSkills(t *testing.T) {
 		}
 	}
 }
+
+func TestDiscoverSkills_SkipsStagingDirs(t *testing.T) {
+	tmpDir := t.TempDir()
+	skill := filepath.Join(tmpDir, "skill")
+	os.Mkdir(skill, 0755)
+	os.WriteFile(filepath.Join(skill, "SKILL.md"), []byte(validSkillMD), 0644)
+	// Left behind by a conversion that crashed while applying
+	stale := filepath.Join(tmpDir, domain.StageDirPrefix+"123", "new")
+	os.MkdirAll(stale, 0755)
+	os.WriteFile(filepath.Join(stale, "SKILL.md"), []byte(validSkillMD), 0644)
+
+	skills, err := DiscoverSkills(tmpDir, true)
+	if err != nil {
+		t.Fatalf("DiscoverSkills failed: %v", err)
+	}
+	if len(skills) != 1 || skills[0].Path != skill {
+		t.Errorf("Expected only %s, got %v", skill, skills)
+	}
+}
+
+func TestDiscoverSkills_InvalidSkill(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	broken := filepath.Join(tmpDir, "broken")
+	os.Mkdir(broken, 0755)
+	os.WriteFile(filepath.Join(broken, "SKILL.md"), []byte("# No frontmatter\n"), 0644)
+
+	skills, err := DiscoverSkills(tmpDir, true)
+	if err != nil {
+		t.Fatalf("DiscoverSkills failed: %v", err)
+	}
+	if len(skills) != 1 {
+		t.Fatalf("Expected broken skill to still be listed, got %d skills", len(skills))
+	}
+	if skills[0].CurrentPlatform != domain.PlatformInvalid {
+		t.Errorf("Expected %s platform, got %s", domain.PlatformInvalid, skills[0].CurrentPlatform)
+	}
+	if skills[0].Detection == nil || len(skills[0].Detection.Issues()) == 0 {
+		t.Error("Expected detection issues to be attached to the skill")
+	}
+}
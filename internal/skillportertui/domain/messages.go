@@ -8,7 +8,30 @@ type SkillsDiscoveredMsg struct {
 // SkillConvertedMsg is sent when a single skill conversion completes successfully
 type SkillConvertedMsg struct {
 	SkillPath string // Using Path as ID
-	Output    string
+	Result    *ConversionResult
+}
+
+// SkillPlannedMsg is sent when a dry run of a single skill completes
+type SkillPlannedMsg struct {
+	SkillPath string // Using Path as ID
+	Changes   []PlannedChange
+	Commands  []CommandPreview
+	Message   string // Set when no conversion would be needed
+	Err       error
+}
+
+// SkillRestoredMsg is sent when undoing an in-place conversion completes
+type SkillRestoredMsg struct {
+	SkillPath string // Using Path as ID
+	Files     int    // Number of files restored or removed
+	Err       error
+}
+
+// SkillRoundtripMsg is sent when a round-trip check of a single skill completes
+type SkillRoundtripMsg struct {
+	SkillPath string // Using Path as ID
+	Report    *RoundtripReport
+	Err       error
 }
 
 // ConversionErrorMsg is sent when a single skill conversion fails
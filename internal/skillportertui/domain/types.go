@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+)
+
 // ConversionStatus represents the state of a skill conversion
 type ConversionStatus string
 
@@ -7,6 +12,8 @@ const (
 	StatusPending ConversionStatus = "Pending"
 	StatusRunning ConversionStatus = "Running"
 	StatusSuccess ConversionStatus = "Success"
+	StatusWarning ConversionStatus = "Warning" // Converted, but with warnings to review
+	StatusSkipped ConversionStatus = "Skipped" // Nothing to convert; see SkillDir.SkipReason
 	StatusFailed  ConversionStatus = "Failed"
 )
 
@@ -14,26 +21,251 @@ const (
 type ConversionTarget string
 
 const (
-	TargetGemini ConversionTarget = "Gemini"
-	TargetClaude ConversionTarget = "Claude"
-	TargetAuto   ConversionTarget = "Auto"
+	TargetGemini    ConversionTarget = "Gemini"
+	TargetClaude    ConversionTarget = "Claude"
+	TargetUniversal ConversionTarget = "Universal"
+	TargetAuto      ConversionTarget = "Auto"
+)
+
+// ConversionBackend selects how conversions are executed
+type ConversionBackend string
+
+const (
+	BackendNative     ConversionBackend = "native"
+	BackendSubprocess ConversionBackend = "subprocess"
+)
+
+// Platform names reported in SkillDir.CurrentPlatform
+const (
+	PlatformClaude       = "Claude"
+	PlatformClaudePlugin = "Claude Plugin" // .claude-plugin/plugin.json with skills/<name>/SKILL.md
+	PlatformGemini       = "Gemini"
+	PlatformUniversal    = "Universal"
+	PlatformInvalid      = "Unknown/Invalid"
 )
 
+// StageDirPrefix names the temporary directories a conversion stages its
+// files in, inside the output directory. Scans and copies skip them.
+const StageDirPrefix = ".skill-porter-stage-"
+
+// DetectedFile is a platform marker file found in a skill directory
+type DetectedFile struct {
+	File  string // Relative path, directories end in "/"
+	Type  string // e.g. "entry", "manifest", "context", "directory"
+	Valid bool
+	Issue string
+}
+
+// SkillMetadata is the identifying information extracted during detection
+type SkillMetadata struct {
+	Name        string
+	Description string
+	Version     string
+}
+
+// Detection is the result of analysing a skill directory
+type Detection struct {
+	Platform    string // One of the Platform* constants
+	Confidence  string // "high" or "low"
+	ClaudeFiles []DetectedFile
+	GeminiFiles []DetectedFile
+	SharedFiles []DetectedFile
+	Metadata    SkillMetadata
+	Diagnostics []Diagnostic // Problems in optional files, which do not affect the platform
+}
+
+// Issues returns the problems found in invalid marker files.
+func (d Detection) Issues() []string {
+	var issues []string
+	for _, files := range [][]DetectedFile{d.ClaudeFiles, d.GeminiFiles} {
+		for _, f := range files {
+			if !f.Valid {
+				issues = append(issues, fmt.Sprintf("%s: %s", f.File, f.Issue))
+			}
+		}
+	}
+	return issues
+}
+
 // SkillDir represents a discovered skill directory and its conversion state
 type SkillDir struct {
 	Name            string
 	Path            string
-	CurrentPlatform string           // e.g. "Claude", "Gemini", "Universal"
+	CurrentPlatform string // e.g. "Claude", "Gemini", "Universal"
+	Detection       *Detection
 	Status          ConversionStatus
 	Target          ConversionTarget
 	OutputPath      string
+	SkipReason      string // Why a Skipped skill needed no conversion
+	Note            string // Status of the last dry run, undo or cancelled review
 	ErrorLog        string
+	Result          *ConversionResult // Outcome of the last conversion
+	Diagnostics     []Diagnostic      // Validation findings from the last conversion
+	Plan            []PlannedChange   // Result of the last dry run
+	Commands        []CommandPreview  // Translated command prompts from the last dry run
+	Roundtrip       *RoundtripReport
+}
+
+// ConversionResult is the outcome of converting a single skill, from either
+// the native converter or the JSON output of the skill-porter CLI.
+type ConversionResult struct {
+	SourcePlatform string // One of the Platform* constants
+	Target         ConversionTarget
+	Files          []string     // Absolute paths of the generated files
+	Warnings       []string     // Conversion warnings, e.g. lossy tool restrictions
+	Validation     []Diagnostic // Findings from validating the output
+	Message        string       // Set when no conversion was needed
+	MCPRewrites    []MCPRewrite // Every change made to MCP server configs
+	Settings       []InferredSetting
+	Lossy          []LossyField     // Source fields the target platform cannot express
+	Commands       []CommandPreview // Each command prompt as translated
+	MissingFiles   []string         // Referenced files that do not exist, as "<file>: <path>"
+	Duration       time.Duration
+}
+
+// CommandPreview is a command prompt as the conversion translated it, for
+// checking placeholders and shell or file injections.
+type CommandPreview struct {
+	Source string   // Source file, relative to the skill
+	Target string   // Generated file, relative to the output
+	Prompt string   // The translated prompt
+	Notes  []string // Lossy rewrites and constructs left untranslated
+}
+
+// LossyField is a field of a source file that the target platform has no
+// equivalent for, so the conversion dropped it.
+type LossyField struct {
+	File   string // Source file, relative to the skill
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (l LossyField) String() string {
+	if l.Value == "" {
+		return fmt.Sprintf("%s: %s (%s)", l.File, l.Field, l.Reason)
+	}
+	return fmt.Sprintf("%s: %s = %s (%s)", l.File, l.Field, l.Value, l.Reason)
+}
+
+// InferredSetting is an extension setting inferred from an environment
+// variable an MCP server reads.
+type InferredSetting struct {
+	Name        string
+	Description string
+	Default     string
+	Secret      bool
+	Required    bool
+	Servers     []string // MCP servers that read the variable
+	Rules       []string // Match patterns of the rules that described it
+}
+
+// MCPRewrite is one change made to an MCP server config while translating it
+// to the other platform.
+type MCPRewrite struct {
+	Server string
+	Field  string // e.g. "args[0]", "env.API_KEY", "type"
+	From   string // Empty when the field was added
+	To     string // Empty when the field was removed
+	Rule   string // ID of the rule that made the change, e.g. "mcp/extension-path"
+	Lossy  bool   // Information was dropped, e.g. an unsupported field
+}
+
+func (r MCPRewrite) String() string {
+	switch {
+	case r.From == "":
+		return fmt.Sprintf("%s.%s: added %s [%s]", r.Server, r.Field, r.To, r.Rule)
+	case r.To == "":
+		return fmt.Sprintf("%s.%s: removed %s [%s]", r.Server, r.Field, r.From, r.Rule)
+	}
+	return fmt.Sprintf("%s.%s: %s → %s [%s]", r.Server, r.Field, r.From, r.To, r.Rule)
+}
+
+// ValidationErrors returns the validation findings of error severity.
+func (r *ConversionResult) ValidationErrors() []Diagnostic {
+	var errs []Diagnostic
+	for _, d := range r.Validation {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
 }
 
 // Summary holds the counts of skills in various states
 type Summary struct {
 	Total   int
 	Success int
+	Warning int
+	Skipped int
 	Failed  int
 	Pending int
 }
+
+// Severity classifies a validation diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single validation finding for a skill or extension
+type Diagnostic struct {
+	RuleID   string
+	Severity Severity
+	File     string // Relative to the skill directory
+	Line     int    // 1-based; 0 when the finding applies to the whole file
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	loc := d.File
+	if d.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", d.File, d.Line)
+	}
+	return fmt.Sprintf("%s: %s [%s] %s", loc, d.Severity, d.RuleID, d.Message)
+}
+
+// FileAction describes what a conversion would do to a file
+type FileAction string
+
+const (
+	ActionCreate    FileAction = "create"
+	ActionOverwrite FileAction = "overwrite"
+	ActionUnchanged FileAction = "unchanged"
+)
+
+// PlannedChange is one file in a dry-run conversion plan
+type PlannedChange struct {
+	Path         string // Relative to the output directory
+	Action       FileAction
+	Size         int64
+	ExistingSize int64 // Size on disk for overwrite/unchanged files
+	Preview      string
+}
+
+// RoundtripFinding is one piece of information lost or altered when a skill
+// is converted to the other platform and back
+type RoundtripFinding struct {
+	Kind    string // e.g. "field-lost", "tools-removed", "command-dropped"
+	Subject string // Field, tool or command the finding is about
+	Detail  string
+	Count   int // Number of items affected, e.g. tools or lines
+}
+
+func (f RoundtripFinding) String() string {
+	if f.Detail == "" {
+		return fmt.Sprintf("%s: %s", f.Kind, f.Subject)
+	}
+	return fmt.Sprintf("%s: %s (%s)", f.Kind, f.Subject, f.Detail)
+}
+
+// RoundtripReport summarises a round trip such as Claude → Gemini → Claude
+type RoundtripReport struct {
+	SkillPath string
+	From      string // Platform the skill started on
+	Via       string // Platform it was converted through
+	Findings  []RoundtripFinding
+	Score     int // 0 (lossless) to 100
+}
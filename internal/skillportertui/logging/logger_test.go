@@ -9,7 +9,7 @@ import (
 
 func TestLogger(t *testing.T) {
 	var buf bytes.Buffer
-	logger := New(&buf)
+	logger := New(&buf, false)
 
 	logger.Info("test message", map[string]string{"key": "value"})
 
@@ -0,0 +1,345 @@
+// Package mcp translates the mcpServers block of a skill between Claude Code
+// (.claude-plugin/marketplace.json and .mcp.json) and Gemini CLI
+// (gemini-extension.json). Every change is made by a named rule and reported
+// as a domain.MCPRewrite, so that nothing about a server changes silently.
+package mcp
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+)
+
+// Rule IDs reported in domain.MCPRewrite.Rule.
+const (
+	RulePluginRoot    = "mcp/plugin-root"    // ${CLAUDE_PLUGIN_ROOT} -> ${extensionPath}
+	RuleRelativePath  = "mcp/relative-path"  // skill-relative path -> ${extensionPath}/path
+	RuleExtensionPath = "mcp/extension-path" // ${extensionPath} -> relative path or ${CLAUDE_PLUGIN_ROOT}
+	RulePathSeparator = "mcp/path-separator" // ${/} and ${pathSeparator} -> /
+	RuleEnvDefault    = "mcp/env-default"    // ${VAR:-default} -> ${VAR}
+	RuleEnvSyntax     = "mcp/env-syntax"     // $VAR -> ${VAR}
+	RuleTransport     = "mcp/transport"      // type/url <-> httpUrl/url
+	RuleToolFilter    = "mcp/tool-filter"    // includeTools/excludeTools, carried by allowed-tools instead
+	RuleUnsupported   = "mcp/unsupported"    // Field the target platform does not support
+)
+
+const (
+	extensionPath = "${extensionPath}"
+	pluginRoot    = "${CLAUDE_PLUGIN_ROOT}"
+)
+
+// PathStyle is how paths into the skill are written in a Claude config.
+type PathStyle int
+
+const (
+	// RelativePaths writes paths relative to the skill, as marketplace.json
+	// has always used.
+	RelativePaths PathStyle = iota
+	// PluginRootPaths prefixes paths with ${CLAUDE_PLUGIN_ROOT}, as a plugin's
+	// .mcp.json needs.
+	PluginRootPaths
+)
+
+var (
+	envDefaultRe = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*):-[^}]*\}`)
+	bareEnvRe    = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// packageRunners are commands whose arguments name packages or images rather
+// than files, so only ./-prefixed arguments are treated as paths.
+var packageRunners = map[string]bool{
+	"npx": true, "bunx": true, "uvx": true, "pipx": true, "npm": true,
+	"pnpm": true, "yarn": true, "docker": true, "podman": true,
+}
+
+// scriptExts are extensions that mark a bare argument such as "server.py"
+// as a file in the skill.
+var scriptExts = map[string]bool{
+	".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".py": true, ".rb": true,
+	".sh": true, ".php": true, ".pl": true, ".lua": true, ".jar": true, ".wasm": true,
+	".json": true, ".yaml": true, ".yml": true, ".toml": true,
+}
+
+// Gemini-only server fields. The tool filters are expressed through
+// allowed-tools when converting to Claude.
+var (
+	toolFilterFields = []string{"includeTools", "excludeTools"}
+	geminiOnlyFields = []string{"timeout", "trust"}
+)
+
+// httpTransports are the Claude transport types Gemini calls httpUrl.
+var httpTransports = map[string]bool{"http": true, "streamable-http": true}
+
+// ToGemini translates Claude mcpServers into the form gemini-extension.json
+// uses. servers is not modified.
+func ToGemini(servers *jsonobj.Object) (*jsonobj.Object, []domain.MCPRewrite) {
+	return translate(servers, func(t *translator, config *jsonobj.Object) *jsonobj.Object {
+		return t.toGemini(config)
+	})
+}
+
+// ToClaude translates Gemini mcpServers into the form Claude Code uses, with
+// paths into the skill written in style. servers is not modified.
+func ToClaude(servers *jsonobj.Object, style PathStyle) (*jsonobj.Object, []domain.MCPRewrite) {
+	return translate(servers, func(t *translator, config *jsonobj.Object) *jsonobj.Object {
+		return t.toClaude(config, style)
+	})
+}
+
+func translate(servers *jsonobj.Object, fn func(*translator, *jsonobj.Object) *jsonobj.Object) (*jsonobj.Object, []domain.MCPRewrite) {
+	out := jsonobj.New()
+	var rewrites []domain.MCPRewrite
+	for _, name := range servers.Keys() {
+		config := servers.Object(name)
+		if config == nil {
+			v, _ := servers.Get(name)
+			out.Set(name, v)
+			continue
+		}
+		t := &translator{server: name}
+		out.Set(name, fn(t, config))
+		rewrites = append(rewrites, t.rewrites...)
+	}
+	return out, rewrites
+}
+
+// translator rewrites a single server and records what it changed.
+type translator struct {
+	server   string
+	rewrites []domain.MCPRewrite
+}
+
+func (t *translator) record(field string, from, to any, rule string, lossy bool) {
+	t.rewrites = append(t.rewrites, domain.MCPRewrite{
+		Server: t.server,
+		Field:  field,
+		From:   display(from),
+		To:     display(to),
+		Rule:   rule,
+		Lossy:  lossy,
+	})
+}
+
+// rewrite applies fn to the string value of field and records the change
+// under rule.
+func (t *translator) rewrite(field, s, rule string, fn func(string) string) string {
+	if out := fn(s); out != s {
+		t.record(field, s, out, rule, false)
+		return out
+	}
+	return s
+}
+
+func (t *translator) toGemini(config *jsonobj.Object) *jsonobj.Object {
+	server := config.Clone()
+	command := config.String("command")
+
+	if command != "" {
+		command = t.rewrite("command", command, RulePluginRoot, toExtensionPath)
+		// A bare command such as "node" is looked up on PATH
+		if isRelativePath(command) && strings.Contains(command, "/") {
+			command = t.rewrite("command", command, RuleRelativePath, prefixExtensionPath)
+		}
+		server.Set("command", command)
+	}
+
+	runner := packageRunners[path.Base(command)]
+	t.mapArgs(server, func(field, arg string) string {
+		arg = t.rewrite(field, arg, RulePluginRoot, toExtensionPath)
+		if isRelativePath(arg) && (strings.HasPrefix(arg, "./") || (!runner && looksLikeFile(arg))) {
+			arg = t.rewrite(field, arg, RuleRelativePath, prefixExtensionPath)
+		}
+		return arg
+	})
+
+	if cwd := config.String("cwd"); cwd != "" {
+		cwd = t.rewrite("cwd", cwd, RulePluginRoot, toExtensionPath)
+		if isRelativePath(cwd) || cwd == "." {
+			cwd = t.rewrite("cwd", cwd, RuleRelativePath, prefixExtensionPath)
+		}
+		server.Set("cwd", cwd)
+	}
+
+	t.mapEnv(server, func(field, value string) string {
+		value = t.rewrite(field, value, RulePluginRoot, toExtensionPath)
+		if envDefaultRe.MatchString(value) {
+			out := envDefaultRe.ReplaceAllString(value, "$${$1}")
+			t.record(field, value, out, RuleEnvDefault, true)
+			value = out
+		}
+		return value
+	})
+
+	// Claude: {"type": "http", "url": ...}; Gemini: {"httpUrl": ...}
+	if typ, ok := config.Get("type"); ok {
+		s, _ := typ.(string)
+		server.Delete("type")
+		t.record("type", s, nil, RuleTransport, false)
+		if url := config.String("url"); url != "" && httpTransports[s] {
+			server.Delete("url")
+			server.Set("httpUrl", url)
+			t.record("url", url, nil, RuleTransport, false)
+			t.record("httpUrl", nil, url, RuleTransport, false)
+		}
+	}
+	return server
+}
+
+func (t *translator) toClaude(config *jsonobj.Object, style PathStyle) *jsonobj.Object {
+	server := config.Clone()
+	paths := func(field, s string) string {
+		s = t.rewrite(field, s, RulePathSeparator, func(s string) string {
+			return strings.NewReplacer("${/}", "/", "${pathSeparator}", "/").Replace(s)
+		})
+		return t.rewrite(field, s, RuleExtensionPath, func(s string) string {
+			return fromExtensionPath(s, style)
+		})
+	}
+
+	if command := config.String("command"); command != "" {
+		server.Set("command", paths("command", command))
+	}
+	t.mapArgs(server, paths)
+	if cwd := config.String("cwd"); cwd != "" {
+		server.Set("cwd", paths("cwd", cwd))
+	}
+	t.mapEnv(server, func(field, value string) string {
+		value = paths(field, value)
+		return t.rewrite(field, value, RuleEnvSyntax, func(s string) string {
+			return bareEnvRe.ReplaceAllString(s, "$${$1}")
+		})
+	})
+
+	// Gemini: httpUrl for streamable HTTP, url for SSE
+	if httpURL := config.String("httpUrl"); httpURL != "" {
+		if url := config.String("url"); url != "" {
+			t.record("url", url, nil, RuleTransport, true)
+		}
+		server.Delete("httpUrl")
+		server.Set("type", "http")
+		server.Set("url", httpURL)
+		t.record("httpUrl", httpURL, nil, RuleTransport, false)
+		t.record("type", nil, "http", RuleTransport, false)
+		t.record("url", nil, httpURL, RuleTransport, false)
+	} else if config.String("url") != "" && config.String("type") == "" {
+		server.Set("type", "sse")
+		t.record("type", nil, "sse", RuleTransport, false)
+	}
+
+	for _, field := range toolFilterFields {
+		t.drop(server, field, RuleToolFilter, false)
+	}
+	for _, field := range geminiOnlyFields {
+		t.drop(server, field, RuleUnsupported, true)
+	}
+	return server
+}
+
+// mapArgs rewrites every string in args with fn.
+func (t *translator) mapArgs(server *jsonobj.Object, fn func(field, arg string) string) {
+	v, ok := server.Get("args")
+	list, isList := v.([]any)
+	if !ok || !isList {
+		return
+	}
+	out := make([]any, len(list))
+	for i, arg := range list {
+		out[i] = arg
+		if s, ok := arg.(string); ok {
+			out[i] = fn(fmt.Sprintf("args[%d]", i), s)
+		}
+	}
+	server.Set("args", out)
+}
+
+// mapEnv rewrites every string value in env with fn.
+func (t *translator) mapEnv(server *jsonobj.Object, fn func(field, value string) string) {
+	env := server.Object("env")
+	if env == nil {
+		return
+	}
+	out := jsonobj.New()
+	for _, key := range env.Keys() {
+		v, _ := env.Get(key)
+		if s, ok := v.(string); ok {
+			v = fn("env."+key, s)
+		}
+		out.Set(key, v)
+	}
+	server.Set("env", out)
+}
+
+func (t *translator) drop(server *jsonobj.Object, field, rule string, lossy bool) {
+	if v, ok := server.Get(field); ok {
+		server.Delete(field)
+		t.record(field, v, nil, rule, lossy)
+	}
+}
+
+func toExtensionPath(s string) string {
+	return strings.ReplaceAll(s, pluginRoot, extensionPath)
+}
+
+func prefixExtensionPath(s string) string {
+	s = strings.TrimPrefix(s, "./")
+	if s == "." || s == "" {
+		return extensionPath
+	}
+	return extensionPath + "/" + s
+}
+
+func fromExtensionPath(s string, style PathStyle) string {
+	if style == PluginRootPaths {
+		return strings.ReplaceAll(s, extensionPath, pluginRoot)
+	}
+	if s == extensionPath {
+		return "."
+	}
+	return strings.ReplaceAll(s, extensionPath+"/", "")
+}
+
+// isRelativePath reports whether s could be a path relative to the skill:
+// not a flag, URL, variable, home or absolute path, and not outside the skill.
+func isRelativePath(s string) bool {
+	switch {
+	case s == "", s == ".":
+		return false
+	case strings.HasPrefix(s, "-"), strings.HasPrefix(s, "$"), strings.HasPrefix(s, "/"),
+		strings.HasPrefix(s, "~"), strings.HasPrefix(s, "\\"), strings.HasPrefix(s, "../"):
+		return false
+	case strings.Contains(s, "://"), strings.Contains(s, "="):
+		return false
+	case len(s) > 1 && s[1] == ':': // C:\ on Windows
+		return false
+	}
+	return true
+}
+
+// looksLikeFile reports whether a relative argument names a file, such as
+// server/index.js or main.py, rather than a package such as @scope/name or
+// name@1.2.3, or a word such as "stdio".
+func looksLikeFile(s string) bool {
+	if strings.Contains(s, "@") {
+		return false
+	}
+	return strings.Contains(s, "/") || scriptExts[strings.ToLower(path.Ext(s))]
+}
+
+// display renders a field value for a rewrite report.
+func display(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := jsonobj.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/jsonobj"
+)
+
+func parseServers(t *testing.T, s string) *jsonobj.Object {
+	t.Helper()
+	servers := jsonobj.New()
+	if err := json.Unmarshal([]byte(s), servers); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	return servers
+}
+
+func compact(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	return string(data)
+}
+
+func TestToGemini(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		rules []string
+		lossy bool
+	}{
+		{
+			name:  "Package runner args untouched",
+			input: `{"s": {"command": "npx", "args": ["-y", "@scope/server", "tools/list"]}}`,
+			want:  `{"s":{"command":"npx","args":["-y","@scope/server","tools/list"]}}`,
+		},
+		{
+			name:  "Relative script",
+			input: `{"s": {"command": "node", "args": ["mcp-server/index.js", "--port", "3000"]}}`,
+			want:  `{"s":{"command":"node","args":["${extensionPath}/mcp-server/index.js","--port","3000"]}}`,
+			rules: []string{RuleRelativePath},
+		},
+		{
+			name:  "Dot-slash arg for a package runner",
+			input: `{"s": {"command": "uvx", "args": ["./server"]}}`,
+			want:  `{"s":{"command":"uvx","args":["${extensionPath}/server"]}}`,
+			rules: []string{RuleRelativePath},
+		},
+		{
+			name:  "Plugin root",
+			input: `{"s": {"command": "${CLAUDE_PLUGIN_ROOT}/bin/server", "cwd": "${CLAUDE_PLUGIN_ROOT}"}}`,
+			want:  `{"s":{"command":"${extensionPath}/bin/server","cwd":"${extensionPath}"}}`,
+			rules: []string{RulePluginRoot, RulePluginRoot},
+		},
+		{
+			name:  "Relative command and cwd",
+			input: `{"s": {"command": "./bin/server", "cwd": "."}}`,
+			want:  `{"s":{"command":"${extensionPath}/bin/server","cwd":"${extensionPath}"}}`,
+			rules: []string{RuleRelativePath, RuleRelativePath},
+		},
+		{
+			name:  "Env default",
+			input: `{"s": {"command": "node", "env": {"URL": "${API_URL:-http://localhost}", "KEY": "${API_KEY}"}}}`,
+			want:  `{"s":{"command":"node","env":{"URL":"${API_URL}","KEY":"${API_KEY}"}}}`,
+			rules: []string{RuleEnvDefault},
+			lossy: true,
+		},
+		{
+			name:  "HTTP transport",
+			input: `{"s": {"type": "http", "url": "https://example.com/mcp"}}`,
+			want:  `{"s":{"httpUrl":"https://example.com/mcp"}}`,
+			rules: []string{RuleTransport, RuleTransport, RuleTransport},
+		},
+		{
+			name:  "SSE transport",
+			input: `{"s": {"type": "sse", "url": "https://example.com/sse"}}`,
+			want:  `{"s":{"url":"https://example.com/sse"}}`,
+			rules: []string{RuleTransport},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := parseServers(t, tt.input)
+			out, rewrites := ToGemini(input)
+			if got := compact(t, out); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+			if got := compact(t, input); got != compact(t, parseServers(t, tt.input)) {
+				t.Errorf("Input was modified: %s", got)
+			}
+			assertRewrites(t, rewrites, tt.rules, tt.lossy)
+		})
+	}
+}
+
+func TestToClaude(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		style PathStyle
+		want  string
+		rules []string
+		lossy bool
+	}{
+		{
+			name:  "Extension path",
+			input: `{"s": {"command": "node", "args": ["${extensionPath}${/}server${/}index.js"], "cwd": "${extensionPath}"}}`,
+			want:  `{"s":{"command":"node","args":["server/index.js"],"cwd":"."}}`,
+			rules: []string{RulePathSeparator, RuleExtensionPath, RuleExtensionPath},
+		},
+		{
+			name:  "Plugin root style",
+			input: `{"s": {"command": "${extensionPath}/bin/server"}}`,
+			style: PluginRootPaths,
+			want:  `{"s":{"command":"${CLAUDE_PLUGIN_ROOT}/bin/server"}}`,
+			rules: []string{RuleExtensionPath},
+		},
+		{
+			name:  "Bare env reference",
+			input: `{"s": {"command": "node", "env": {"TOKEN": "$GITHUB_TOKEN", "HOME": "${HOME}"}}}`,
+			want:  `{"s":{"command":"node","env":{"TOKEN":"${GITHUB_TOKEN}","HOME":"${HOME}"}}}`,
+			rules: []string{RuleEnvSyntax},
+		},
+		{
+			name:  "HTTP transport",
+			input: `{"s": {"httpUrl": "https://example.com/mcp"}}`,
+			want:  `{"s":{"type":"http","url":"https://example.com/mcp"}}`,
+			rules: []string{RuleTransport, RuleTransport, RuleTransport},
+		},
+		{
+			name:  "SSE transport",
+			input: `{"s": {"url": "https://example.com/sse"}}`,
+			want:  `{"s":{"url":"https://example.com/sse","type":"sse"}}`,
+			rules: []string{RuleTransport},
+		},
+		{
+			name:  "Gemini-only fields",
+			input: `{"s": {"command": "node", "timeout": 30000, "trust": true, "includeTools": ["query"]}}`,
+			want:  `{"s":{"command":"node"}}`,
+			rules: []string{RuleToolFilter, RuleUnsupported, RuleUnsupported},
+			lossy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, rewrites := ToClaude(parseServers(t, tt.input), tt.style)
+			if got := compact(t, out); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+			assertRewrites(t, rewrites, tt.rules, tt.lossy)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	claude := `{"db":{"command":"node","args":["server/index.js","--verbose"],"env":{"DB_URL":"${DB_URL}"}},"remote":{"type":"http","url":"https://example.com/mcp"}}`
+	gemini, _ := ToGemini(parseServers(t, claude))
+	back, _ := ToClaude(gemini, RelativePaths)
+	if got := compact(t, back); got != claude {
+		t.Errorf("Round trip changed the servers:\ngot  %s\nwant %s", got, claude)
+	}
+}
+
+func TestMCPRewrite_String(t *testing.T) {
+	_, rewrites := ToClaude(parseServers(t, `{"db": {"command": "${extensionPath}/server", "timeout": 5000}}`), RelativePaths)
+	var lines []string
+	for _, r := range rewrites {
+		lines = append(lines, r.String())
+	}
+	want := "db.command: ${extensionPath}/server → server [mcp/extension-path]\n" +
+		"db.timeout: removed 5000 [mcp/unsupported]"
+	if got := strings.Join(lines, "\n"); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func assertRewrites(t *testing.T, rewrites []domain.MCPRewrite, rules []string, lossy bool) {
+	t.Helper()
+	var got []string
+	anyLossy := false
+	for _, r := range rewrites {
+		got = append(got, r.Rule)
+		anyLossy = anyLossy || r.Lossy
+	}
+	if strings.Join(got, ",") != strings.Join(rules, ",") {
+		t.Errorf("Expected rules %v, got %v", rules, got)
+	}
+	if anyLossy != lossy {
+		t.Errorf("Expected lossy %v, got %v", lossy, anyLossy)
+	}
+}
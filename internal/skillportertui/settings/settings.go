@@ -0,0 +1,159 @@
+// Package settings infers the settings of a Gemini extension from the
+// environment variables its MCP servers read. What is known about a variable
+// comes from a rules file: a default is embedded in the binary and users can
+// add their own rules for team-specific variables.
+package settings
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the newest rules format this build understands.
+const Version = 1
+
+//go:embed rules.yaml
+var defaultRules []byte
+
+// Rule describes the variables whose whole name matches Match. Fields left
+// unset are taken from the next matching rule.
+type Rule struct {
+	Match       string  `yaml:"match"`
+	Description string  `yaml:"description,omitempty"`
+	Default     *string `yaml:"default,omitempty"`
+	Secret      *bool   `yaml:"secret,omitempty"`
+	Required    *bool   `yaml:"required,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Rules is an ordered list of rules.
+type Rules struct {
+	Version int    `yaml:"version"`
+	Rules   []Rule `yaml:"rules"`
+}
+
+// Default returns the rules embedded in the binary.
+func Default() *Rules {
+	r, err := parse(defaultRules)
+	if err != nil {
+		panic(fmt.Sprintf("embedded settings rules: %v", err))
+	}
+	return r
+}
+
+// DefaultPath is where a user's rules are looked for when no path is given.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "skill-porter", "settings.yaml")
+}
+
+// Load returns the user rules at path followed by the default rules. An empty
+// path returns the default rules.
+func Load(path string) (*Rules, error) {
+	r := Default()
+	if path == "" {
+		return r, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	user, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	user.Rules = append(user.Rules, r.Rules...)
+	return user, nil
+}
+
+// Resolve is Load for the command line: with no path it picks up the user's
+// rules at DefaultPath when there are some.
+func Resolve(path string) (*Rules, error) {
+	if path == "" {
+		if info, err := os.Stat(DefaultPath()); err == nil && !info.IsDir() {
+			path = DefaultPath()
+		}
+	}
+	return Load(path)
+}
+
+func parse(data []byte) (*Rules, error) {
+	var r Rules
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("invalid settings rules: %w", err)
+	}
+	switch {
+	case r.Version == 0:
+		return nil, errors.New("settings rules are missing their version")
+	case r.Version > Version:
+		return nil, fmt.Errorf("settings rules version %d is newer than this build supports (%d)", r.Version, Version)
+	}
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+		if rule.Match == "" {
+			return nil, fmt.Errorf("settings rule %d has no match pattern", i+1)
+		}
+		re, err := regexp.Compile(`^(?:` + rule.Match + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("settings rule %d: invalid match pattern: %w", i+1, err)
+		}
+		rule.re = re
+	}
+	return &r, nil
+}
+
+// Infer describes the variable called name. A variable no rule describes is
+// described by its name, e.g. CACHE_DIR becomes "Cache Dir".
+func (r *Rules) Infer(name string) domain.InferredSetting {
+	s := domain.InferredSetting{Name: name}
+	var description, def, secret, required bool
+	for _, rule := range r.Rules {
+		if !rule.re.MatchString(name) {
+			continue
+		}
+		used := false
+		if !description && rule.Description != "" {
+			s.Description, description, used = rule.Description, true, true
+		}
+		if !def && rule.Default != nil {
+			s.Default, def, used = *rule.Default, true, true
+		}
+		if !secret && rule.Secret != nil {
+			s.Secret, secret, used = *rule.Secret, true, true
+		}
+		if !required && rule.Required != nil {
+			s.Required, required, used = *rule.Required, true, true
+		}
+		if used {
+			s.Rules = append(s.Rules, rule.Match)
+		}
+	}
+	if !description {
+		s.Description = describe(name)
+	}
+	return s
+}
+
+// describe turns a variable name into a description: DB_CONN_STRING becomes
+// "Db Conn String".
+func describe(name string) string {
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = word[:1] + strings.ToLower(word[1:])
+		}
+	}
+	return strings.Join(words, " ")
+}
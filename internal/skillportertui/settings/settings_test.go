@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	r := Default()
+
+	tests := []struct {
+		name        string
+		description string
+		def         string
+		secret      bool
+	}{
+		{"DB_HOST", "Database server hostname", "localhost", false},
+		{"DB_PASSWORD", "Database password", "", true},
+		{"API_KEY", "API authentication key", "", true},
+		{"GITHUB_TOKEN", "GitHub personal access token", "", true},
+		{"api_key", "api key", "", true}, // Credential words match in any case
+		{"PRIVATE_KEY_PATH", "Private Key Path", "", true},
+		{"MONKEY_PATH", "Monkey Path", "", false},
+		{"TOKENIZER_MODEL", "Tokenizer Model", "", false},
+		{"CACHE_DIR", "Cache Dir", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := r.Infer(tt.name)
+			if s.Description != tt.description || s.Default != tt.def || s.Secret != tt.secret || s.Required != tt.secret {
+				t.Errorf("Got %+v, want description %q, default %q, secret and required %v", s, tt.description, tt.def, tt.secret)
+			}
+		})
+	}
+
+	if s := r.Infer("CACHE_DIR"); len(s.Rules) != 0 {
+		t.Errorf("Expected no rules to match CACHE_DIR, got %v", s.Rules)
+	}
+	if s := r.Infer("DB_PASSWORD"); len(s.Rules) != 2 {
+		t.Errorf("Expected a specific and a generic rule to describe DB_PASSWORD, got %v", s.Rules)
+	}
+}
+
+func TestLoad_UserRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.yaml")
+	os.WriteFile(path, []byte(`version: 1
+rules:
+  - match: ACME_.*
+    description: ACME platform setting
+  - match: ACME_TENANT
+    default: internal
+  - match: ACME_SIGNING_KEY_ID
+    secret: false
+    required: true
+`), 0644)
+
+	r, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if s := r.Infer("ACME_TENANT"); s.Description != "ACME platform setting" || s.Default != "internal" {
+		t.Errorf("Expected rules to combine field by field, got %+v", s)
+	}
+	if s := r.Infer("ACME_SIGNING_KEY_ID"); s.Secret || !s.Required {
+		t.Errorf("Expected the user rule to override the generic secret rule, got %+v", s)
+	}
+	if s := r.Infer("DB_HOST"); s.Default != "localhost" {
+		t.Errorf("Expected the default rules to still apply, got %+v", s)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"No version", "rules: []\n", "missing their version"},
+		{"Newer version", "version: 99\nrules: []\n", "newer than this build"},
+		{"No pattern", "version: 1\nrules:\n  - description: x\n", "has no match pattern"},
+		{"Bad pattern", "version: 1\nrules:\n  - match: \"(\"\n", "invalid match pattern"},
+		{"Not YAML", "version: [1\n", "invalid settings rules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.yaml")
+			os.WriteFile(path, []byte(tt.content), 0644)
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
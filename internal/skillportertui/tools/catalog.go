@@ -0,0 +1,177 @@
+// Package tools maps tool names between Claude Code and Gemini CLI. The
+// mapping is a catalog file: a default is embedded in the binary and users
+// can extend or override it with their own.
+package tools
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the newest catalog format this build understands.
+const Version = 1
+
+//go:embed catalog.yaml
+var defaultCatalog []byte
+
+// Tool is one tool and its name on each platform. A name is empty when the
+// platform has no equivalent.
+type Tool struct {
+	Claude  string   `yaml:"claude,omitempty"`
+	Gemini  string   `yaml:"gemini,omitempty"`
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// Name returns the tool's name on platform (domain.PlatformClaude or
+// domain.PlatformGemini).
+func (t Tool) Name(platform string) string {
+	if platform == domain.PlatformGemini {
+		return t.Gemini
+	}
+	return t.Claude
+}
+
+// Catalog is a list of tools.
+type Catalog struct {
+	Version int    `yaml:"version"`
+	Tools   []Tool `yaml:"tools"`
+}
+
+// Default returns the catalog embedded in the binary.
+func Default() *Catalog {
+	c, err := parse(defaultCatalog)
+	if err != nil {
+		panic(fmt.Sprintf("embedded tool catalog: %v", err))
+	}
+	return c
+}
+
+// DefaultPath is where a user's catalog is looked for when no path is given.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "skill-porter", "tools.yaml")
+}
+
+// Load returns the default catalog merged with the user catalog at path.
+// An empty path returns the default catalog.
+func Load(path string) (*Catalog, error) {
+	c := Default()
+	if path == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	user, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	c.Merge(user)
+	return c, nil
+}
+
+// Resolve is Load for the command line: with no path it picks up the user's
+// catalog at DefaultPath when there is one.
+func Resolve(path string) (*Catalog, error) {
+	if path == "" {
+		if info, err := os.Stat(DefaultPath()); err == nil && !info.IsDir() {
+			path = DefaultPath()
+		}
+	}
+	return Load(path)
+}
+
+func parse(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid tool catalog: %w", err)
+	}
+	switch {
+	case c.Version == 0:
+		return nil, errors.New("tool catalog is missing its version")
+	case c.Version > Version:
+		return nil, fmt.Errorf("tool catalog version %d is newer than this build supports (%d)", c.Version, Version)
+	}
+	for i, t := range c.Tools {
+		if t.Claude == "" && t.Gemini == "" {
+			return nil, fmt.Errorf("tool catalog entry %d has neither a claude nor a gemini name", i+1)
+		}
+	}
+	return &c, nil
+}
+
+// Merge adds the tools of other to c. A tool with the same Claude or Gemini
+// name as one already in c replaces it.
+func (c *Catalog) Merge(other *Catalog) {
+	for _, t := range other.Tools {
+		replaced := false
+		for i, existing := range c.Tools {
+			if (t.Claude != "" && t.Claude == existing.Claude) || (t.Gemini != "" && t.Gemini == existing.Gemini) {
+				c.Tools[i] = t
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			c.Tools = append(c.Tools, t)
+		}
+	}
+}
+
+// Lookup finds the tool called name on platform. Names from the other
+// platform and aliases are accepted too, so a Gemini manifest that lists
+// Claude tool names still resolves.
+func (c *Catalog) Lookup(platform, name string) (Tool, bool) {
+	for _, t := range c.Tools {
+		if t.Name(platform) == name {
+			return t, true
+		}
+	}
+	for _, t := range c.Tools {
+		if t.Claude == name || t.Gemini == name {
+			return t, true
+		}
+		for _, alias := range t.Aliases {
+			if strings.EqualFold(alias, name) {
+				return t, true
+			}
+		}
+	}
+	return Tool{}, false
+}
+
+// IsMCP reports whether name refers to a tool served by an MCP server, such
+// as mcp__db__query or mcp__db for every tool of the db server.
+func IsMCP(name string) bool {
+	return strings.HasPrefix(name, "mcp__")
+}
+
+// SplitMCP splits an MCP tool name into its server and tool. tool is empty
+// for a name that covers the whole server.
+func SplitMCP(name string) (server, tool string, ok bool) {
+	if !IsMCP(name) {
+		return "", "", false
+	}
+	server, tool, _ = strings.Cut(strings.TrimPrefix(name, "mcp__"), "__")
+	return server, tool, server != ""
+}
+
+// MCPName is the Claude name of tool on server, or of the whole server when
+// tool is empty.
+func MCPName(server, tool string) string {
+	if tool == "" {
+		return "mcp__" + server
+	}
+	return "mcp__" + server + "__" + tool
+}
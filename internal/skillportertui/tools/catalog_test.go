@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func TestDefault(t *testing.T) {
+	c := Default()
+	if c.Version != Version {
+		t.Errorf("Expected version %d, got %d", Version, c.Version)
+	}
+
+	var claude []string
+	for _, tool := range c.Tools {
+		if tool.Claude != "" {
+			claude = append(claude, tool.Claude)
+		}
+	}
+	if len(claude) != 16 {
+		t.Errorf("Expected the 16 Claude tools, got %v", claude)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c := Default()
+
+	tests := []struct {
+		platform string
+		name     string
+		claude   string
+		gemini   string
+	}{
+		{domain.PlatformClaude, "Read", "Read", "read_file"},
+		{domain.PlatformGemini, "read_file", "Read", "read_file"},
+		{domain.PlatformGemini, "Bash", "Bash", "run_shell_command"},  // Claude name in a Gemini manifest
+		{domain.PlatformGemini, "shell", "Bash", "run_shell_command"}, // Alias, any case
+		{domain.PlatformClaude, "MultiEdit", "Edit", "replace"},
+		{domain.PlatformGemini, "list_directory", "", "list_directory"},
+		{domain.PlatformClaude, "Task", "Task", ""},
+	}
+	for _, tt := range tests {
+		tool, ok := c.Lookup(tt.platform, tt.name)
+		if !ok {
+			t.Errorf("%s %q: not found", tt.platform, tt.name)
+			continue
+		}
+		if tool.Claude != tt.claude || tool.Gemini != tt.gemini {
+			t.Errorf("%s %q: got %q/%q, want %q/%q", tt.platform, tt.name, tool.Claude, tool.Gemini, tt.claude, tt.gemini)
+		}
+	}
+
+	if _, ok := c.Lookup(domain.PlatformClaude, "Teleport"); ok {
+		t.Error("Expected unknown tool not to be found")
+	}
+}
+
+func TestLoad_Override(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	os.WriteFile(path, []byte(`version: 1
+tools:
+  - claude: Bash
+    gemini: shell_v2
+  - claude: Deploy
+    gemini: deploy_app
+    aliases: [ship]
+`), 0644)
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if tool, _ := c.Lookup(domain.PlatformClaude, "Bash"); tool.Gemini != "shell_v2" {
+		t.Errorf("Expected Bash to map to shell_v2, got %q", tool.Gemini)
+	}
+	if tool, ok := c.Lookup(domain.PlatformGemini, "ship"); !ok || tool.Claude != "Deploy" {
+		t.Errorf("Expected the new tool to be found by its alias, got %v", tool)
+	}
+	if len(c.Tools) != len(Default().Tools)+1 {
+		t.Errorf("Expected one tool to be added, got %d tools", len(c.Tools))
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"No version", "tools: []\n", "missing its version"},
+		{"Newer version", "version: 99\ntools: []\n", "newer than this build"},
+		{"Nameless tool", "version: 1\ntools:\n  - aliases: [x]\n", "neither a claude nor a gemini name"},
+		{"Not YAML", "version: [1\n", "invalid tool catalog"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tools.yaml")
+			os.WriteFile(path, []byte(tt.content), 0644)
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSplitMCP(t *testing.T) {
+	tests := []struct {
+		name, server, tool string
+		ok                 bool
+	}{
+		{"mcp__db__query", "db", "query", true},
+		{"mcp__db", "db", "", true},
+		{"mcp__github__create_issue", "github", "create_issue", true},
+		{"Read", "", "", false},
+	}
+	for _, tt := range tests {
+		server, tool, ok := SplitMCP(tt.name)
+		if server != tt.server || tool != tt.tool || ok != tt.ok {
+			t.Errorf("SplitMCP(%q) = %q, %q, %v; want %q, %q, %v", tt.name, server, tool, ok, tt.server, tt.tool, tt.ok)
+		}
+		if ok && MCPName(server, tool) != tt.name {
+			t.Errorf("MCPName(%q, %q) = %q, want %q", server, tool, MCPName(server, tool), tt.name)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/config"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/discovery"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/logging"
@@ -14,6 +15,7 @@ type SessionState int
 const (
 	StateConfig SessionState = iota
 	StateBrowsing
+	StateReview
 )
 
 type Model struct {
@@ -24,19 +26,27 @@ type Model struct {
 	Inputs     []textinput.Model
 	FocusIndex int
 	// For toggles that aren't text inputs
-	// 0: Root (Text), 1: Output (Text), 2: Recursive (Bool), 3: Target (Enum), 4: Submit (Btn)
+	// 0: Root (Text), 1: Output (Text), 2: Recursive (Bool), 3: Target (Enum), 4: Backend (Enum), 5: Submit (Btn)
 
 	// Browsing View State
 	Skills       []domain.SkillDir
 	Cursor       int
 	SuccessCount int
+	WarningCount int
+	SkippedCount int
 	FailCount    int
 	Err          error
 
+	// Review View State
+	review      *review
+	reviewQueue []*review
+
 	// Internal state
-	width  int
-	height int
-	logger *logging.Logger
+	width   int
+	height  int
+	logger  *logging.Logger
+	backups *conversion.BackupStore // Snapshots of in-place conversions, for undo
+	options conversion.Options      // Built from Config
 }
 
 func NewModel(cfg *config.AppConfig, logger *logging.Logger) Model {
@@ -47,6 +57,13 @@ func NewModel(cfg *config.AppConfig, logger *logging.Logger) Model {
 		State:  StateConfig,
 	}
 
+	backupRoot := cfg.BackupDir
+	if backupRoot == "" {
+		backupRoot = conversion.DefaultBackupRoot()
+	}
+	m.backups = conversion.NewBackupStore(backupRoot)
+	m.options = conversion.Options{Tools: cfg.Tools, Settings: cfg.SettingsRules, SplitPlugins: cfg.SplitPlugins}
+
 	// Initialize Inputs
 	m.Inputs = make([]textinput.Model, 2)
 
@@ -76,6 +93,73 @@ func (m Model) Init() tea.Cmd {
 	return textinput.Blink
 }
 
+// setStatus moves the skill at idx to status, keeping the counts in step.
+func (m *Model) setStatus(idx int, status domain.ConversionStatus) {
+	if c := m.counter(m.Skills[idx].Status); c != nil {
+		*c--
+	}
+	if c := m.counter(status); c != nil {
+		*c++
+	}
+	m.Skills[idx].Status = status
+}
+
+// counter returns the count kept for status, or nil for uncounted states.
+func (m *Model) counter(status domain.ConversionStatus) *int {
+	switch status {
+	case domain.StatusSuccess:
+		return &m.SuccessCount
+	case domain.StatusWarning:
+		return &m.WarningCount
+	case domain.StatusSkipped:
+		return &m.SkippedCount
+	case domain.StatusFailed:
+		return &m.FailCount
+	}
+	return nil
+}
+
+// Summary returns the number of skills in each state. Running skills count
+// as pending.
+func (m Model) Summary() domain.Summary {
+	total := len(m.Skills)
+	return domain.Summary{
+		Total:   total,
+		Success: m.SuccessCount,
+		Warning: m.WarningCount,
+		Skipped: m.SkippedCount,
+		Failed:  m.FailCount,
+		Pending: total - m.SuccessCount - m.WarningCount - m.SkippedCount - m.FailCount,
+	}
+}
+
+// ExitCode is 1 when any conversion failed and 0 otherwise. With
+// --fail-on-warning it is 2 when conversions succeeded but left warnings to
+// review. Skipped skills never fail a run.
+func (m Model) ExitCode() int {
+	switch {
+	case m.FailCount > 0:
+		return 1
+	case m.WarningCount > 0 && m.Config != nil && m.Config.FailOnWarning:
+		return 2
+	}
+	return 0
+}
+
+// undoSkillCmd restores a skill from its latest snapshot in this session.
+func undoSkillCmd(backups *conversion.BackupStore, skill domain.SkillDir) tea.Cmd {
+	return func() tea.Msg {
+		snap, err := backups.Latest(skill.Path)
+		if err != nil {
+			return domain.SkillRestoredMsg{SkillPath: skill.Path, Err: err}
+		}
+		if err := snap.Restore(); err != nil {
+			return domain.SkillRestoredMsg{SkillPath: skill.Path, Err: err}
+		}
+		return domain.SkillRestoredMsg{SkillPath: skill.Path, Files: len(snap.Files)}
+	}
+}
+
 func discoverSkillsCmd(root string, recursive bool) tea.Cmd {
 	return func() tea.Msg {
 		skills, err := discovery.DiscoverSkills(root, recursive)
@@ -2,10 +2,14 @@ package ui
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/config"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
 )
 
@@ -17,6 +21,7 @@ func TestUpdate_Conversion(t *testing.T) {
 	}
 	m := Model{
 		Config: cfg,
+		State:  StateBrowsing,
 		Skills: skills,
 		Cursor: 0,
 	}
@@ -35,7 +40,11 @@ func TestUpdate_Conversion(t *testing.T) {
 	// 2. Handle Success Message
 	successMsg := domain.SkillConvertedMsg{
 		SkillPath: "/tmp/s1",
-		Output:    "Success Output",
+		Result: &domain.ConversionResult{
+			SourcePlatform: domain.PlatformClaude,
+			Target:         domain.TargetGemini,
+			Files:          []string{"/tmp/s1/gemini-extension.json"},
+		},
 	}
 	newM, _ = newModel.Update(successMsg)
 	newModel = newM.(Model)
@@ -46,6 +55,9 @@ func TestUpdate_Conversion(t *testing.T) {
 	if newModel.SuccessCount != 1 {
 		t.Errorf("Expected success count 1, got %d", newModel.SuccessCount)
 	}
+	if newModel.Skills[0].Result != successMsg.Result {
+		t.Errorf("Expected conversion result to be stored on the skill, got %v", newModel.Skills[0].Result)
+	}
 
 	// 3. Handle Error Message
 	// Reset model
@@ -65,4 +77,404 @@ func TestUpdate_Conversion(t *testing.T) {
 	if newModel.FailCount != 1 {
 		t.Errorf("Expected fail count 1, got %d", newModel.FailCount)
 	}
-}
\ No newline at end of file
+}
+func TestUpdate_ValidationDiagnostics(t *testing.T) {
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Skill1", Path: "/tmp/s1", Status: domain.StatusRunning},
+			{Name: "Skill2", Path: "/tmp/s2", Status: domain.StatusRunning},
+		},
+	}
+
+	warnOnly := domain.SkillConvertedMsg{
+		SkillPath: "/tmp/s1",
+		Result: &domain.ConversionResult{Validation: []domain.Diagnostic{
+			{RuleID: "claude/description-short", Severity: domain.SeverityWarning, File: "SKILL.md", Line: 3},
+		}},
+	}
+	newM, _ := m.Update(warnOnly)
+	m = newM.(Model)
+
+	if m.Skills[0].Status != domain.StatusWarning {
+		t.Errorf("Expected warnings-only conversion to need review, got %s", m.Skills[0].Status)
+	}
+	if len(m.Skills[0].Diagnostics) != 1 {
+		t.Errorf("Expected diagnostics to be stored on the skill, got %v", m.Skills[0].Diagnostics)
+	}
+
+	withError := domain.SkillConvertedMsg{
+		SkillPath: "/tmp/s2",
+		Result: &domain.ConversionResult{Validation: []domain.Diagnostic{
+			{RuleID: "gemini/version-required", Severity: domain.SeverityError, File: "gemini-extension.json"},
+		}},
+	}
+	newM, _ = m.Update(withError)
+	m = newM.(Model)
+
+	if m.Skills[1].Status != domain.StatusFailed {
+		t.Errorf("Expected validation errors to fail the conversion, got %s", m.Skills[1].Status)
+	}
+	if m.WarningCount != 1 || m.FailCount != 1 || m.SuccessCount != 0 {
+		t.Errorf("Expected 1 warning and 1 failure, got %d/%d/%d", m.SuccessCount, m.WarningCount, m.FailCount)
+	}
+	if m.ExitCode() != 1 {
+		t.Errorf("Expected exit code 1 with a failure, got %d", m.ExitCode())
+	}
+}
+
+func TestUpdate_SkippedAndWarningStatuses(t *testing.T) {
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Universal", Path: "/tmp/u", Status: domain.StatusRunning},
+			{Name: "Lossy", Path: "/tmp/l", Status: domain.StatusRunning},
+			{Name: "Clean", Path: "/tmp/c", Status: domain.StatusRunning},
+		},
+	}
+
+	msgs := []domain.SkillConvertedMsg{
+		{SkillPath: "/tmp/u", Result: &domain.ConversionResult{Message: "Already a universal skill/extension - no conversion needed"}},
+		{SkillPath: "/tmp/l", Result: &domain.ConversionResult{Warnings: []string{"Tool restrictions may not translate exactly"}}},
+		{SkillPath: "/tmp/c", Result: &domain.ConversionResult{}},
+	}
+	for _, msg := range msgs {
+		newM, _ := m.Update(msg)
+		m = newM.(Model)
+	}
+
+	tests := []struct {
+		idx    int
+		status domain.ConversionStatus
+	}{
+		{0, domain.StatusSkipped},
+		{1, domain.StatusWarning},
+		{2, domain.StatusSuccess},
+	}
+	for _, tt := range tests {
+		if m.Skills[tt.idx].Status != tt.status {
+			t.Errorf("%s: expected %s, got %s", m.Skills[tt.idx].Name, tt.status, m.Skills[tt.idx].Status)
+		}
+	}
+	if m.Skills[0].SkipReason == "" {
+		t.Error("Expected skipped skill to record a reason")
+	}
+
+	want := domain.Summary{Total: 3, Success: 1, Warning: 1, Skipped: 1}
+	if got := m.Summary(); got != want {
+		t.Errorf("Expected summary %+v, got %+v", want, got)
+	}
+	if m.ExitCode() != 0 {
+		t.Errorf("Expected exit code 0 for warnings by default, got %d", m.ExitCode())
+	}
+	m.Config.FailOnWarning = true
+	if m.ExitCode() != 2 {
+		t.Errorf("Expected exit code 2 for warnings with --fail-on-warning, got %d", m.ExitCode())
+	}
+
+	// Skipped skills alone never fail a run
+	m.setStatus(1, domain.StatusPending)
+	if m.ExitCode() != 0 || m.WarningCount != 0 {
+		t.Errorf("Expected exit code 0 with only skips, got %d (%d warnings)", m.ExitCode(), m.WarningCount)
+	}
+}
+
+func TestUpdate_InvalidSkillNotConverted(t *testing.T) {
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Broken", Path: "/tmp/broken", CurrentPlatform: domain.PlatformInvalid, Status: domain.StatusPending},
+		},
+	}
+
+	for _, key := range []rune{'c', 'g', 'a', 'A'} {
+		newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		if newM.(Model).Skills[0].Status != domain.StatusPending {
+			t.Errorf("%q: expected invalid skill to stay Pending, got %s", key, newM.(Model).Skills[0].Status)
+		}
+		if cmd != nil {
+			t.Errorf("%q: expected no conversion command for invalid skill", key)
+		}
+	}
+}
+
+func TestUpdate_MakeUniversal(t *testing.T) {
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Skill1", Path: "/tmp/s1", CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending},
+			{Name: "Skill2", Path: "/tmp/s2", CurrentPlatform: domain.PlatformGemini, Status: domain.StatusPending},
+		},
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	newModel := newM.(Model)
+	for _, s := range newModel.Skills {
+		if s.Status != domain.StatusRunning {
+			t.Errorf("Expected %s to be Running, got %s", s.Name, s.Status)
+		}
+	}
+	if cmd == nil {
+		t.Error("Expected batch command, got nil")
+	}
+}
+
+func TestUpdate_RunningSkillNotRestarted(t *testing.T) {
+	// A skill is Running while it converts and while it waits in review
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Skill1", Path: "/tmp/s1", CurrentPlatform: domain.PlatformClaude, Status: domain.StatusRunning},
+		},
+	}
+
+	for _, key := range []rune{'c', 'g', 'a', 'm'} {
+		if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}}); cmd != nil {
+			t.Errorf("%q: expected no second conversion of a running skill", key)
+		}
+	}
+}
+
+func TestUpdate_DryRun(t *testing.T) {
+	m := Model{
+		Config: &config.AppConfig{DryRun: true},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "Skill1", Path: "/tmp/s1", CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending},
+		},
+	}
+
+	for _, key := range []rune{'c', 'd', 'A'} {
+		newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		if newM.(Model).Skills[0].Status != domain.StatusPending {
+			t.Errorf("%q: expected dry run to leave status Pending, got %s", key, newM.(Model).Skills[0].Status)
+		}
+		if cmd == nil {
+			t.Errorf("%q: expected a dry-run command, got nil", key)
+		}
+	}
+
+	planned := domain.SkillPlannedMsg{
+		SkillPath: "/tmp/s1",
+		Changes: []domain.PlannedChange{
+			{Path: "gemini-extension.json", Action: domain.ActionCreate, Size: 120},
+		},
+		Commands: []domain.CommandPreview{
+			{Source: ".claude/commands/review.md", Target: "commands/review.toml", Prompt: "Review @{src/main.go}"},
+		},
+	}
+	newM, _ := m.Update(planned)
+	m = newM.(Model)
+
+	if len(m.Skills[0].Plan) != 1 {
+		t.Errorf("Expected plan to be stored on the skill, got %v", m.Skills[0].Plan)
+	}
+	if view := m.View(); !strings.Contains(view, "Review @{src/main.go}") {
+		t.Errorf("Expected the command preview in the details pane, got:\n%s", view)
+	}
+	if m.Skills[0].Status != domain.StatusPending || m.SuccessCount != 0 {
+		t.Errorf("Expected dry run not to count as a conversion, got %s (%d successes)", m.Skills[0].Status, m.SuccessCount)
+	}
+}
+
+func TestUpdate_ReviewOverwrites(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill for review\n---\n\nBody\n"), 0644)
+	outBase := t.TempDir()
+	out := filepath.Join(outBase, "demo")
+	os.MkdirAll(out, 0755)
+	os.WriteFile(filepath.Join(out, "gemini-extension.json"), []byte("{}"), 0644)
+	os.WriteFile(filepath.Join(out, "GEMINI.md"), []byte("old context\n"), 0644)
+
+	m := Model{
+		Config: &config.AppConfig{OutBaseDir: outBase},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "demo", Path: src, CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending},
+		},
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = newM.(Model)
+	staged, ok := cmd().(skillStagedMsg)
+	if !ok {
+		t.Fatal("Expected conversion to be staged for review")
+	}
+	if len(staged.review.files) != 2 {
+		t.Fatalf("Expected 2 files to review, got %v", staged.review.files)
+	}
+
+	newM, _ = m.Update(staged)
+	m = newM.(Model)
+	if m.State != StateReview {
+		t.Fatalf("Expected review state, got %v", m.State)
+	}
+	if fileExists(filepath.Join(out, "docs", "GEMINI_ARCHITECTURE.md")) {
+		t.Error("Expected nothing to be written before the review is applied")
+	}
+
+	// Reject GEMINI.md and accept the rest
+	for i, f := range m.review.files {
+		if f.Path == "GEMINI.md" {
+			m.review.current = i
+		}
+	}
+	newM, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newM.(Model)
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newM.(Model)
+	if m.State != StateBrowsing {
+		t.Errorf("Expected to return to browsing after apply, got %v", m.State)
+	}
+
+	newM, _ = m.Update(cmd())
+	m = newM.(Model)
+	if m.Skills[0].Status != domain.StatusSuccess {
+		t.Errorf("Expected Success after apply, got %s (%s)", m.Skills[0].Status, m.Skills[0].ErrorLog)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, "GEMINI.md")); string(data) != "old context\n" {
+		t.Errorf("Expected rejected GEMINI.md to be left alone, got %q", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(out, "gemini-extension.json")); string(data) == "{}" {
+		t.Error("Expected accepted gemini-extension.json to be overwritten")
+	}
+	if !fileExists(filepath.Join(out, "docs", "GEMINI_ARCHITECTURE.md")) {
+		t.Error("Expected new files to be written")
+	}
+}
+
+func TestUpdate_ReviewInferredSettings(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill with settings\n---\n\nBody\n"), 0644)
+	os.WriteFile(filepath.Join(src, ".mcp.json"), []byte(`{"mcpServers": {"db": {"command": "db-mcp", "env": {"DB_HOST": "${DB_HOST}", "DB_PASSWORD": "${DB_PASSWORD}"}}}}`), 0644)
+
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "demo", Path: src, CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending},
+		},
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = newM.(Model)
+	staged, ok := cmd().(skillStagedMsg)
+	if !ok {
+		t.Fatal("Expected inferred settings to be staged for review")
+	}
+	if len(staged.review.files) != 0 || len(staged.review.settings) != 2 {
+		t.Fatalf("Expected 2 settings and no overwrites to review, got %v and %v", staged.review.settings, staged.review.files)
+	}
+
+	newM, _ = m.Update(staged)
+	m = newM.(Model)
+	view := m.View()
+	for _, want := range []string{"DB_HOST", "localhost", "Database password"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected review to show %q:\n%s", want, view)
+		}
+	}
+	if fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Error("Expected nothing to be written before the review is applied")
+	}
+
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	newM, _ = newM.(Model).Update(cmd())
+	m = newM.(Model)
+	if m.Skills[0].Status == domain.StatusFailed || !fileExists(filepath.Join(src, "gemini-extension.json")) {
+		t.Errorf("Expected the conversion to be written after apply, got %s (%s)", m.Skills[0].Status, m.Skills[0].ErrorLog)
+	}
+}
+
+func TestUpdate_Undo(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill for undo\n---\n\nBody\n"), 0644)
+
+	m := Model{
+		Config:  &config.AppConfig{},
+		State:   StateBrowsing,
+		backups: conversion.NewBackupStore(t.TempDir()),
+		Skills: []domain.SkillDir{
+			{Name: "demo", Path: src, CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending},
+		},
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	newM, _ = newM.(Model).Update(cmd())
+	m = newM.(Model)
+	if m.Skills[0].Status == domain.StatusFailed || !fileExists(filepath.Join(src, "GEMINI.md")) {
+		t.Fatalf("Expected in-place conversion to succeed, got %s (%s)", m.Skills[0].Status, m.Skills[0].ErrorLog)
+	}
+
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	newM, _ = newM.(Model).Update(cmd())
+	m = newM.(Model)
+
+	if m.Skills[0].Status != domain.StatusPending || m.SuccessCount+m.WarningCount != 0 {
+		t.Errorf("Expected undo to reset the skill to Pending, got %s (%+v)", m.Skills[0].Status, m.Summary())
+	}
+	entries, _ := os.ReadDir(src)
+	if len(entries) != 1 {
+		t.Errorf("Expected only SKILL.md after undo, found %d entries", len(entries))
+	}
+	if s := m.Skills[0]; s.OutputPath != "" || !strings.HasPrefix(s.Note, "Restored ") {
+		t.Errorf("Expected the undo to be noted, not reported as output, got %q and %q", s.OutputPath, s.Note)
+	}
+	if view := m.View(); !strings.Contains(view, "Note:") {
+		t.Errorf("Expected the note in the details pane, got:\n%s", view)
+	}
+
+	// Nothing left to undo
+	newM, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	newM, _ = newM.(Model).Update(cmd())
+	if newM.(Model).Skills[0].ErrorLog == "" {
+		t.Error("Expected an error when there is nothing to undo")
+	}
+}
+
+func TestUpdate_Roundtrip(t *testing.T) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: demo\ndescription: Demo skill for round trips\nextra: kept?\n---\n\nBody\n"), 0644)
+
+	m := Model{
+		Config: &config.AppConfig{},
+		State:  StateBrowsing,
+		Skills: []domain.SkillDir{
+			{Name: "demo", Path: src, CurrentPlatform: domain.PlatformClaude, Status: domain.StatusPending, ErrorLog: "Round trip failed: earlier"},
+		},
+	}
+
+	newM, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("Expected a round-trip command, got nil")
+	}
+	newM, _ = newM.(Model).Update(cmd())
+	m = newM.(Model)
+
+	report := m.Skills[0].Roundtrip
+	if report == nil {
+		t.Fatalf("Expected a round-trip report, got none (%s)", m.Skills[0].ErrorLog)
+	}
+	if report.Score == 0 {
+		t.Errorf("Expected the unknown frontmatter field to cost points, got %v", report.Findings)
+	}
+	if m.Skills[0].ErrorLog != "" {
+		t.Errorf("Expected a successful round trip to clear the earlier error, got %q", m.Skills[0].ErrorLog)
+	}
+	if m.Skills[0].Status != domain.StatusPending {
+		t.Errorf("Expected round trip to leave status Pending, got %s", m.Skills[0].Status)
+	}
+	if fileExists(filepath.Join(src, "GEMINI.md")) {
+		t.Error("Expected round trip not to write into the skill")
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+var (
+	diffAddStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	diffDelStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffHunkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+)
+
+// reviewFile is an existing file the staged conversion would change.
+type reviewFile struct {
+	Path     string
+	Diff     []string
+	Accepted bool
+}
+
+// review holds a staged conversion whose overwrites and inferred settings
+// await confirmation. Nothing is written until the review is applied.
+type review struct {
+	skill   domain.SkillDir
+	target  domain.ConversionTarget
+	outDir  string
+	result  *conversion.Result
+	backups *conversion.BackupStore
+	planned time.Duration // Time spent planning, counted in the duration
+
+	files    []reviewFile
+	settings []domain.InferredSetting
+	current  int
+	offset   int
+}
+
+// skillStagedMsg is sent when a conversion would overwrite existing files or
+// write inferred settings.
+type skillStagedMsg struct {
+	review *review
+}
+
+// newReview returns a review of every planned file that differs from what is
+// on disk and of the inferred settings, or nil when the plan only creates
+// files and infers no settings.
+func newReview(s domain.SkillDir, target domain.ConversionTarget, outDir string, result *conversion.Result, planned time.Duration) *review {
+	if result.Plan == nil {
+		return nil
+	}
+	r := &review{skill: s, target: target, outDir: outDir, result: result, planned: planned, settings: result.Settings}
+	for _, c := range result.Plan.Changes() {
+		if c.Action != domain.ActionOverwrite {
+			continue
+		}
+		diff := strings.Split(strings.TrimSuffix(result.Plan.Diff(c.Path), "\n"), "\n")
+		r.files = append(r.files, reviewFile{Path: c.Path, Diff: diff, Accepted: true})
+	}
+	if len(r.files) == 0 && len(r.settings) == 0 {
+		return nil
+	}
+	return r
+}
+
+// enqueueReview shows r, or queues it behind the review already on screen.
+func (m *Model) enqueueReview(r *review) {
+	if m.review != nil {
+		m.reviewQueue = append(m.reviewQueue, r)
+		return
+	}
+	m.review = r
+	m.State = StateReview
+}
+
+// nextReview moves on to the next queued review, if any.
+func (m *Model) nextReview() {
+	m.review = nil
+	m.State = StateBrowsing
+	if len(m.reviewQueue) > 0 {
+		r := m.reviewQueue[0]
+		m.reviewQueue = m.reviewQueue[1:]
+		m.enqueueReview(r)
+	}
+}
+
+func (m Model) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Conversions running in the background still report back
+		return m.updateBrowsing(msg)
+	}
+
+	r := m.review
+	switch key.String() {
+	case "enter":
+		m.nextReview()
+		return m, applyReviewCmd(r)
+	case "esc":
+		for i := range m.Skills {
+			if m.Skills[i].Path == r.skill.Path {
+				m.setStatus(i, domain.StatusPending)
+				m.Skills[i].Note = "Conversion cancelled during review; nothing was written"
+				break
+			}
+		}
+		m.nextReview()
+		return m, nil
+	}
+	if len(r.files) == 0 {
+		return m, nil
+	}
+
+	file := &r.files[r.current]
+	switch key.String() {
+	case "down", "j":
+		if r.offset < len(file.Diff)-1 {
+			r.offset++
+		}
+	case "up", "k":
+		if r.offset > 0 {
+			r.offset--
+		}
+	case "pgdown":
+		r.offset = min(r.offset+m.diffHeight(), max(len(file.Diff)-1, 0))
+	case "pgup":
+		r.offset = max(r.offset-m.diffHeight(), 0)
+	case "tab", "right", "l":
+		r.current = (r.current + 1) % len(r.files)
+		r.offset = 0
+	case "shift+tab", "left", "h":
+		r.current = (r.current + len(r.files) - 1) % len(r.files)
+		r.offset = 0
+	case "y":
+		file.Accepted = true
+	case "n":
+		file.Accepted = false
+	case " ":
+		file.Accepted = !file.Accepted
+	}
+	return m, nil
+}
+
+// applyReviewCmd writes the staged conversion, leaving rejected files as
+// they are on disk.
+func applyReviewCmd(r *review) tea.Cmd {
+	return func() tea.Msg {
+		// Time spent in the review screen is not part of the conversion
+		start := time.Now().Add(-r.planned)
+		for _, f := range r.files {
+			if !f.Accepted {
+				r.result.Plan.Remove(f.Path)
+			}
+		}
+		if err := conversion.ApplyPlan(context.Background(), r.result.Plan, r.skill.Path, r.outDir, r.backups); err != nil {
+			return domain.ConversionErrorMsg{SkillPath: r.skill.Path, Err: err}
+		}
+		return finishConversion(r.skill, r.target, r.outDir, r.result, start)
+	}
+}
+
+// diffHeight is the number of diff lines that fit on screen.
+func (m Model) diffHeight() int {
+	if m.height <= 0 {
+		return 20
+	}
+	return max(m.height-12, 5)
+}
+
+func (m Model) viewReview() string {
+	r := m.review
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Review Changes") + "\n\n")
+	if len(r.settings) > 0 {
+		b.WriteString(fmt.Sprintf("%s → %s: %d setting(s) inferred from MCP server environments\n\n", r.skill.Name, r.target, len(r.settings)))
+		b.WriteString(renderSettings(r.settings) + "\n")
+	}
+	if len(r.files) == 0 {
+		b.WriteString(footerStyle.Render("Keys: Enter: Apply • Esc: Cancel"))
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("%s → %s: %d existing file(s) would change\n\n", r.skill.Name, r.target, len(r.files)))
+
+	for i, f := range r.files {
+		mark := statusSuccessStyle.Render("[✓]")
+		if !f.Accepted {
+			mark = statusFailStyle.Render("[✗]")
+		}
+		style := itemStyle
+		cursor := " "
+		if i == r.current {
+			style = selectedItemStyle
+			cursor = ">"
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s %s %s", cursor, mark, f.Path)) + "\n")
+	}
+	b.WriteString("\n")
+
+	file := r.files[r.current]
+	end := min(r.offset+m.diffHeight(), len(file.Diff))
+	for _, line := range file.Diff[r.offset:end] {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			line = diffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = diffAddStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = diffDelStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	position := fmt.Sprintf("Lines %d-%d of %d", r.offset+1, end, len(file.Diff))
+	help := "\nKeys: ↑/↓: Scroll • Tab/←/→: File • y/n/Space: Accept/Reject • Enter: Apply • Esc: Cancel"
+	b.WriteString(footerStyle.Render(position + help))
+	return b.String()
+}
+
+// renderSettings lays out inferred settings as a table, with the rules that
+// described each one so a wrong guess can be traced to its rule.
+func renderSettings(settings []domain.InferredSetting) string {
+	rows := [][]string{{"NAME", "SECRET", "REQUIRED", "DEFAULT", "DESCRIPTION", "SERVERS"}}
+	for _, s := range settings {
+		rows = append(rows, []string{s.Name, yesNo(s.Secret), yesNo(s.Required), s.Default, s.Description, strings.Join(s.Servers, ", ")})
+	}
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for i, row := range rows {
+		var line strings.Builder
+		for j, cell := range row {
+			line.WriteString(cell + strings.Repeat(" ", widths[j]-lipgloss.Width(cell)+2))
+		}
+		text := "  " + strings.TrimRight(line.String(), " ")
+		switch {
+		case i == 0:
+			text = statusPendingStyle.Render(text)
+		case settings[i-1].Secret:
+			text = statusWarningStyle.Render(text)
+		}
+		b.WriteString(text + "\n")
+	}
+	for _, s := range settings {
+		if len(s.Rules) == 0 {
+			b.WriteString(statusPendingStyle.Render(fmt.Sprintf("  %s: no rule matched; description made from the name", s.Name)) + "\n")
+		}
+	}
+	return b.String()
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
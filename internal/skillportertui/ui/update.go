@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
@@ -10,7 +11,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/config"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/discovery"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/validation"
 )
 
 // Focus indices
@@ -19,8 +22,9 @@ const (
 	inputOutput
 	toggleRecursive
 	toggleTarget
+	toggleBackend
 	btnSubmit
-	fieldCount // 5
+	fieldCount // 6
 )
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -38,6 +42,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.State == StateConfig {
 		return m.updateConfig(msg)
 	}
+	if m.State == StateReview && m.review != nil {
+		return m.updateReview(msg)
+	}
 
 	return m.updateBrowsing(msg)
 }
@@ -82,10 +89,20 @@ func (m Model) updateConfig(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case domain.TargetGemini:
 					m.Config.DefaultTarget = domain.TargetClaude
 				case domain.TargetClaude:
+					m.Config.DefaultTarget = domain.TargetUniversal
+				case domain.TargetUniversal:
 					m.Config.DefaultTarget = domain.TargetAuto
 				}
 				return m, nil
 			}
+			if (s == "enter" || s == " ") && m.FocusIndex == toggleBackend {
+				if m.Config.Backend == domain.BackendSubprocess {
+					m.Config.Backend = domain.BackendNative
+				} else {
+					m.Config.Backend = domain.BackendSubprocess
+				}
+				return m, nil
+			}
 
 			// Navigation
 			if s == "up" || s == "shift+tab" {
@@ -140,30 +157,39 @@ func (m Model) updateBrowsing(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.Cursor++
 			}
 		case "c":
-			if len(m.Skills) > 0 {
-				idx := m.Cursor
-				skill := &m.Skills[idx]
-				if skill.Status == domain.StatusPending || skill.Status == domain.StatusFailed {
-					m.Skills[idx].Status = domain.StatusRunning
-					cmd = convertSkillCmd(skill, m.Config, domain.TargetAuto)
-				}
+			if len(m.Skills) > 0 && canStart(m.Skills[m.Cursor]) {
+				cmd = m.startConversion(m.Cursor, domain.TargetAuto)
 			}
 		case "g":
-			if len(m.Skills) > 0 {
-				idx := m.Cursor
-				m.Skills[idx].Status = domain.StatusRunning
-				cmd = convertSkillCmd(&m.Skills[idx], m.Config, domain.TargetGemini)
+			if len(m.Skills) > 0 && canStart(m.Skills[m.Cursor]) {
+				cmd = m.startConversion(m.Cursor, domain.TargetGemini)
 			}
 		case "a":
-			if len(m.Skills) > 0 {
-				idx := m.Cursor
-				m.Skills[idx].Status = domain.StatusRunning
-				cmd = convertSkillCmd(&m.Skills[idx], m.Config, domain.TargetClaude)
+			if len(m.Skills) > 0 && canStart(m.Skills[m.Cursor]) {
+				cmd = m.startConversion(m.Cursor, domain.TargetClaude)
+			}
+		case "m":
+			if len(m.Skills) > 0 && canStart(m.Skills[m.Cursor]) {
+				cmd = m.startConversion(m.Cursor, domain.TargetUniversal)
+			}
+		case "d": // Dry run the selected skill
+			if len(m.Skills) > 0 && isConvertible(m.Skills[m.Cursor]) {
+				cmd = planSkillCmd(&m.Skills[m.Cursor], m.Config, domain.TargetAuto, m.options)
+			}
+		case "t": // Round trip the selected skill to measure conversion loss
+			if len(m.Skills) > 0 && isConvertible(m.Skills[m.Cursor]) {
+				cmd = roundtripSkillCmd(m.Skills[m.Cursor], m.options)
+			}
+		case "u": // Undo the last in-place conversion of the selected skill
+			if len(m.Skills) > 0 && m.backups != nil && m.Skills[m.Cursor].Status != domain.StatusRunning {
+				cmd = undoSkillCmd(m.backups, m.Skills[m.Cursor])
 			}
 		case "r":
 			m.Skills = []domain.SkillDir{}
 			m.Cursor = 0
 			m.SuccessCount = 0
+			m.WarningCount = 0
+			m.SkippedCount = 0
 			m.FailCount = 0
 			// Need to re-trigger discovery based on CURRENT config (which might have been edited in Setup)
 			cmd = discoverSkillsCmd(m.Config.ScanRoot, m.Config.RecursiveMode)
@@ -171,9 +197,18 @@ func (m Model) updateBrowsing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "A": // Auto-Convert All Pending
 			var cmds []tea.Cmd
 			for i := range m.Skills {
-				if m.Skills[i].Status == domain.StatusPending {
-					m.Skills[i].Status = domain.StatusRunning
-					cmds = append(cmds, convertSkillCmd(&m.Skills[i], m.Config, domain.TargetAuto))
+				if m.Skills[i].Status == domain.StatusPending && isConvertible(m.Skills[i]) {
+					cmds = append(cmds, m.startConversion(i, domain.TargetAuto))
+				}
+			}
+			if len(cmds) > 0 {
+				cmd = tea.Batch(cmds...)
+			}
+		case "M": // Make All Pending Universal
+			var cmds []tea.Cmd
+			for i := range m.Skills {
+				if m.Skills[i].Status == domain.StatusPending && isConvertible(m.Skills[i]) {
+					cmds = append(cmds, m.startConversion(i, domain.TargetUniversal))
 				}
 			}
 			if len(cmds) > 0 {
@@ -192,14 +227,81 @@ func (m Model) updateBrowsing(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Skills = msg.Skills
 		m.Cursor = 0
 		m.SuccessCount = 0
+		m.WarningCount = 0
+		m.SkippedCount = 0
 		m.FailCount = 0
 
 	case domain.SkillConvertedMsg:
 		for i := range m.Skills {
 			if m.Skills[i].Path == msg.SkillPath {
-				m.Skills[i].Status = domain.StatusSuccess
-				m.Skills[i].OutputPath = msg.Output
-				m.SuccessCount++
+				m.Skills[i].Result = msg.Result
+				m.Skills[i].OutputPath = ""
+				m.Skills[i].Note = ""
+				m.Skills[i].SkipReason = ""
+				m.Skills[i].ErrorLog = ""
+				m.Skills[i].Diagnostics = msg.Result.Validation
+				switch errs := msg.Result.ValidationErrors(); {
+				case msg.Result.Message != "":
+					m.Skills[i].SkipReason = msg.Result.Message
+					m.setStatus(i, domain.StatusSkipped)
+				case len(errs) > 0:
+					m.Skills[i].ErrorLog = fmt.Sprintf("Validation failed with %d error(s)", len(errs))
+					m.setStatus(i, domain.StatusFailed)
+				case len(msg.Result.Validation) > 0 || len(msg.Result.Warnings) > 0 || len(msg.Result.Lossy) > 0 || len(msg.Result.MissingFiles) > 0:
+					m.setStatus(i, domain.StatusWarning)
+				default:
+					m.setStatus(i, domain.StatusSuccess)
+				}
+				break
+			}
+		}
+
+	case skillStagedMsg:
+		m.enqueueReview(msg.review)
+
+	case domain.SkillPlannedMsg:
+		for i := range m.Skills {
+			if m.Skills[i].Path == msg.SkillPath {
+				// A dry run never changes the conversion status
+				m.Skills[i].Plan = msg.Changes
+				m.Skills[i].Commands = msg.Commands
+				m.Skills[i].Note = msg.Message
+				m.Skills[i].ErrorLog = ""
+				if msg.Err != nil {
+					m.Skills[i].ErrorLog = msg.Err.Error()
+				}
+				break
+			}
+		}
+
+	case domain.SkillRoundtripMsg:
+		for i := range m.Skills {
+			if m.Skills[i].Path == msg.SkillPath {
+				// Round trips run in a temp directory and never change the status
+				m.Skills[i].Roundtrip = msg.Report
+				m.Skills[i].ErrorLog = ""
+				if msg.Err != nil {
+					m.Skills[i].ErrorLog = "Round trip failed: " + msg.Err.Error()
+				}
+				break
+			}
+		}
+
+	case domain.SkillRestoredMsg:
+		for i := range m.Skills {
+			if m.Skills[i].Path == msg.SkillPath {
+				if msg.Err != nil {
+					m.Skills[i].ErrorLog = "Undo failed: " + msg.Err.Error()
+					break
+				}
+				m.setStatus(i, domain.StatusPending)
+				m.Skills[i].Note = fmt.Sprintf("Restored %d file(s) from backup", msg.Files)
+				m.Skills[i].SkipReason = ""
+				m.Skills[i].Result = nil
+				m.Skills[i].ErrorLog = ""
+				m.Skills[i].Diagnostics = nil
+				m.Skills[i].Plan = nil
+				m.Skills[i].Commands = nil
 				break
 			}
 		}
@@ -207,9 +309,8 @@ func (m Model) updateBrowsing(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case domain.ConversionErrorMsg:
 		for i := range m.Skills {
 			if m.Skills[i].Path == msg.SkillPath {
-				m.Skills[i].Status = domain.StatusFailed
+				m.setStatus(i, domain.StatusFailed)
 				m.Skills[i].ErrorLog = msg.Err.Error()
-				m.FailCount++
 				break
 			}
 		}
@@ -218,47 +319,136 @@ func (m Model) updateBrowsing(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func convertSkillCmd(skill *domain.SkillDir, cfg *config.AppConfig, override domain.ConversionTarget) tea.Cmd {
+// isConvertible reports whether detection found a usable skill. Broken skills
+// are listed so they can be inspected, but are never handed to a converter.
+func isConvertible(skill domain.SkillDir) bool {
+	return skill.CurrentPlatform != domain.PlatformInvalid
+}
+
+// canStart reports whether a conversion of skill can be started: it must be
+// convertible and not already running or waiting in review.
+func canStart(skill domain.SkillDir) bool {
+	switch skill.Status {
+	case domain.StatusPending, domain.StatusFailed, domain.StatusWarning:
+		return isConvertible(skill)
+	}
+	return false
+}
+
+// startConversion converts the skill at idx, or only plans the conversion
+// when dry-run mode is enabled.
+func (m *Model) startConversion(idx int, override domain.ConversionTarget) tea.Cmd {
+	if m.Config.DryRun {
+		return planSkillCmd(&m.Skills[idx], m.Config, override, m.options)
+	}
+	m.setStatus(idx, domain.StatusRunning)
+	return convertSkillCmd(&m.Skills[idx], m.Config, override, m.backups, m.options)
+}
+
+// resolveTarget picks the conversion target: the explicit override, then the
+// skill's own target, then the configured default, then the opposite platform.
+func resolveTarget(s domain.SkillDir, cfg *config.AppConfig, override domain.ConversionTarget) domain.ConversionTarget {
+	target := override
+
+	if target == domain.TargetAuto {
+		target = s.Target
+	}
+
+	if target == domain.TargetAuto {
+		target = cfg.DefaultTarget
+	}
+
+	if target == domain.TargetAuto {
+		target = discovery.AutoTarget(s.CurrentPlatform)
+	}
+	return target
+}
+
+// skillOutDir returns the output directory for a skill, or "" for in place.
+func skillOutDir(s domain.SkillDir, cfg *config.AppConfig) string {
+	if cfg.OutBaseDir == "" {
+		return ""
+	}
+	return filepath.Join(cfg.OutBaseDir, s.Name)
+}
+
+func convertSkillCmd(skill *domain.SkillDir, cfg *config.AppConfig, override domain.ConversionTarget, backups *conversion.BackupStore, opts conversion.Options) tea.Cmd {
 	s := *skill
 	return func() tea.Msg {
-		target := override
-		
-		if target == domain.TargetAuto {
-			target = s.Target
-		}
-		
-		if target == domain.TargetAuto {
-			target = cfg.DefaultTarget
-		}
-		
-		if target == domain.TargetAuto {
-			if s.CurrentPlatform == "Claude" {
-				target = domain.TargetGemini
-			} else if s.CurrentPlatform == "Gemini" {
-				target = domain.TargetClaude
-			} else {
-				target = domain.TargetGemini
-			}
-		}
-
-		outDir := cfg.OutBaseDir
-		if outDir != "" {
-			outDir = filepath.Join(outDir, s.Name)
-		}
+		target := resolveTarget(s, cfg, override)
+		outDir := skillOutDir(s, cfg)
 
-		args, err := conversion.BuildConvertCommand(s.Path, target, outDir)
+		converter, err := conversion.NewConverter(cfg.Backend, backups, opts)
 		if err != nil {
 			return domain.ConversionErrorMsg{SkillPath: s.Path, Err: err}
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
+		start := time.Now()
 
-		output, err := conversion.ExecuteCommand(ctx, "skill-porter", args)
+		// Stage the conversion when possible so overwrites can be reviewed
+		if planner, ok := converter.(conversion.Planner); ok {
+			result, err := planner.Plan(ctx, s, target, outDir)
+			if err != nil {
+				return domain.ConversionErrorMsg{SkillPath: s.Path, Err: err}
+			}
+			if r := newReview(s, target, outDir, result, time.Since(start)); r != nil {
+				r.backups = backups
+				return skillStagedMsg{review: r}
+			}
+			if err := conversion.ApplyPlan(ctx, result.Plan, s.Path, outDir, backups); err != nil {
+				return domain.ConversionErrorMsg{SkillPath: s.Path, Err: err}
+			}
+			return finishConversion(s, target, outDir, result, start)
+		}
+
+		result, err := converter.Convert(ctx, s, target, outDir)
 		if err != nil {
 			return domain.ConversionErrorMsg{SkillPath: s.Path, Err: err}
 		}
+		return finishConversion(s, target, outDir, result, start)
+	}
+}
 
-		return domain.SkillConvertedMsg{SkillPath: s.Path, Output: output}
+// finishConversion validates the generated files unless nothing was converted,
+// and reports the outcome of a conversion that began at start.
+func finishConversion(s domain.SkillDir, target domain.ConversionTarget, outDir string, result *conversion.Result, start time.Time) tea.Msg {
+	res := result.ConversionResult(target, time.Since(start))
+	if result.Message == "" {
+		validateDir := outDir
+		if validateDir == "" {
+			validateDir = s.Path
+		}
+		res.Validation = validation.Validate(validateDir, string(target))
 	}
-}
\ No newline at end of file
+
+	return domain.SkillConvertedMsg{SkillPath: s.Path, Result: res}
+}
+
+// planSkillCmd runs a conversion without writing anything. Plans are always
+// built natively since the subprocess CLI has no dry-run mode.
+func planSkillCmd(skill *domain.SkillDir, cfg *config.AppConfig, override domain.ConversionTarget, opts conversion.Options) tea.Cmd {
+	s := *skill
+	return func() tea.Msg {
+		target := resolveTarget(s, cfg, override)
+
+		result, err := conversion.PlanConversion(s.Path, target, skillOutDir(s, cfg), opts)
+		if err != nil {
+			return domain.SkillPlannedMsg{SkillPath: s.Path, Err: err}
+		}
+		if result.Plan == nil {
+			return domain.SkillPlannedMsg{SkillPath: s.Path, Message: result.Message}
+		}
+		return domain.SkillPlannedMsg{SkillPath: s.Path, Changes: result.Plan.Changes(), Commands: result.Commands}
+	}
+}
+
+// roundtripSkillCmd converts a skill to the other platform and back in a temp
+// directory and reports what was lost on the way.
+func roundtripSkillCmd(skill domain.SkillDir, opts conversion.Options) tea.Cmd {
+	return func() tea.Msg {
+		report, err := conversion.Roundtrip(skill.Path, opts)
+		return domain.SkillRoundtripMsg{SkillPath: skill.Path, Report: report, Err: err}
+	}
+}
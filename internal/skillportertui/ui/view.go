@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
@@ -33,6 +35,8 @@ var (
 	statusPendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 	statusRunningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
 	statusSuccessStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	statusWarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	statusSkippedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("67"))
 	statusFailStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 
 	footerStyle = lipgloss.NewStyle().
@@ -66,6 +70,9 @@ func (m Model) View() string {
 	if m.State == StateConfig {
 		return m.viewConfig()
 	}
+	if m.State == StateReview && m.review != nil {
+		return m.viewReview()
+	}
 	return m.viewBrowsing()
 }
 
@@ -99,7 +106,18 @@ targetStyle := blurredStyle
 	}
 	b.WriteString(targetStyle.Render(fmt.Sprintf("Default Target: < %s >", m.Config.DefaultTarget)) + "\n\n")
 
-	// 5. Submit Button
+	// 5. Backend Select
+	backendStyle := blurredStyle
+	if m.FocusIndex == toggleBackend {
+		backendStyle = focusedStyle
+	}
+	backend := m.Config.Backend
+	if backend == "" {
+		backend = domain.BackendNative
+	}
+	b.WriteString(backendStyle.Render(fmt.Sprintf("Backend: < %s >", backend)) + "\n\n")
+
+	// 6. Submit Button
 	btn := buttonStyle.Render("Start Scanning")
 	if m.FocusIndex == btnSubmit {
 		btn = activeButtonStyle.Render("Start Scanning")
@@ -136,6 +154,10 @@ func (m Model) viewBrowsing() string {
 			statusStr = statusRunningStyle.Render(status)
 		case domain.StatusSuccess:
 			statusStr = statusSuccessStyle.Render(status)
+		case domain.StatusWarning:
+			statusStr = statusWarningStyle.Render(status)
+		case domain.StatusSkipped:
+			statusStr = statusSkippedStyle.Render(status)
 		case domain.StatusFailed:
 			statusStr = statusFailStyle.Render(status)
 		default:
@@ -154,13 +176,58 @@ func (m Model) viewBrowsing() string {
 		detailsBuilder.WriteString(fmt.Sprintf("Name: %s\n", selected.Name))
 		detailsBuilder.WriteString(fmt.Sprintf("Path: %s\n", selected.Path))
 		detailsBuilder.WriteString(fmt.Sprintf("Platform: %s\n", selected.CurrentPlatform))
+		if det := selected.Detection; det != nil {
+			detailsBuilder.WriteString(fmt.Sprintf("Confidence: %s\n", det.Confidence))
+			if det.Metadata.Version != "" {
+				detailsBuilder.WriteString(fmt.Sprintf("Version: %s\n", det.Metadata.Version))
+			}
+			if det.Metadata.Description != "" {
+				detailsBuilder.WriteString(fmt.Sprintf("Description: %s\n", det.Metadata.Description))
+			}
+			detailsBuilder.WriteString(renderDetectedFiles("Claude files", det.ClaudeFiles))
+			detailsBuilder.WriteString(renderDetectedFiles("Gemini files", det.GeminiFiles))
+			detailsBuilder.WriteString(renderDetectedFiles("Shared files", det.SharedFiles))
+			for _, d := range det.Diagnostics {
+				detailsBuilder.WriteString(statusRunningStyle.Render("  "+d.String()) + "\n")
+			}
+		}
 		detailsBuilder.WriteString(fmt.Sprintf("Target: %s\n", selected.Target))
-		detailsBuilder.WriteString(fmt.Sprintf("Status: %s\n", selected.Status))
+		if selected.Status == domain.StatusSkipped && selected.SkipReason != "" {
+			detailsBuilder.WriteString(fmt.Sprintf("Status: %s (%s)\n", selected.Status, selected.SkipReason))
+		} else {
+			detailsBuilder.WriteString(fmt.Sprintf("Status: %s\n", selected.Status))
+		}
 		
+		if len(selected.Diagnostics) > 0 {
+			detailsBuilder.WriteString("\nDiagnostics:\n")
+			for _, d := range selected.Diagnostics {
+				style := statusRunningStyle
+				if d.Severity == domain.SeverityError {
+					style = statusFailStyle
+				}
+				detailsBuilder.WriteString(style.Render("  "+d.String()) + "\n")
+			}
+		}
+
+		if len(selected.Plan) > 0 {
+			detailsBuilder.WriteString(renderPlan(selected.Plan))
+			detailsBuilder.WriteString(renderCommands(selected.Commands))
+		}
+
+		if selected.Result != nil {
+			detailsBuilder.WriteString(renderResult(selected.Path, selected.Result))
+		}
+		if selected.Roundtrip != nil {
+			detailsBuilder.WriteString(renderRoundtrip(selected.Roundtrip))
+		}
 		if selected.OutputPath != "" {
 			detailsBuilder.WriteString("\nOutput:\n")
 			detailsBuilder.WriteString(selected.OutputPath)
 		}
+		if selected.Note != "" {
+			detailsBuilder.WriteString("\nNote:\n")
+			detailsBuilder.WriteString(selected.Note)
+		}
 		if selected.ErrorLog != "" {
 			detailsBuilder.WriteString("\nError:\n")
 			detailsBuilder.WriteString(selected.ErrorLog)
@@ -169,13 +236,15 @@ func (m Model) viewBrowsing() string {
 	detailsView := detailsStyle.Render(detailsBuilder.String())
 
 	// Render Footer
-	total := len(m.Skills)
-
-pending := total - m.SuccessCount - m.FailCount
-	summary := fmt.Sprintf("Total: %d | Success: %d | Failed: %d | Pending: %d", 
-		total, m.SuccessCount, m.FailCount, pending)
+	counts := m.Summary()
+	summary := fmt.Sprintf("Total: %d | Success: %d | Warning: %d | Skipped: %d | Failed: %d | Pending: %d",
+		counts.Total, counts.Success, counts.Warning, counts.Skipped, counts.Failed, counts.Pending)
+	
+	if m.Config.DryRun {
+		summary = "DRY RUN | " + summary
+	}
 	
-	help := "\nKeys: ↑/↓: Navigate • c: Convert • g/a: Force Target • A: All • Esc: Config • q: Quit"
+	help := "\nKeys: ↑/↓: Navigate • c: Convert • d: Dry Run • t: Round Trip • u: Undo • g/a: Force Target • m/M: Universal (One/All) • A: All • Esc: Config • q: Quit"
 	footerView := footerStyle.Render(summary + help)
 
 	// Layout
@@ -183,3 +252,151 @@ pending := total - m.SuccessCount - m.FailCount
 	
 	return lipgloss.JoinVertical(lipgloss.Left, title, mainView, footerView)
 }
+
+func renderDetectedFiles(heading string, files []domain.DetectedFile) string {
+	if len(files) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(heading + ":\n")
+	for _, f := range files {
+		if f.Valid {
+			b.WriteString(statusSuccessStyle.Render("  ✓ ") + f.File + "\n")
+		} else {
+			b.WriteString(statusFailStyle.Render("  ✗ ") + f.File + " (" + f.Issue + ")\n")
+		}
+	}
+	return b.String()
+}
+
+// renderPlan lists the files a dry run would touch, with a short preview of
+// each created or overwritten file.
+func renderPlan(changes []domain.PlannedChange) string {
+	counts := map[domain.FileAction]int{}
+	for _, c := range changes {
+		counts[c.Action]++
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nDry run: %d create, %d overwrite, %d unchanged\n",
+		counts[domain.ActionCreate], counts[domain.ActionOverwrite], counts[domain.ActionUnchanged]))
+	for _, c := range changes {
+		switch c.Action {
+		case domain.ActionCreate:
+			b.WriteString(statusSuccessStyle.Render("  + create    ") + fmt.Sprintf("%s (%d B)\n", c.Path, c.Size))
+		case domain.ActionOverwrite:
+			b.WriteString(statusRunningStyle.Render("  ~ overwrite") + fmt.Sprintf(" %s (%d B, was %d B)\n", c.Path, c.Size, c.ExistingSize))
+		default:
+			b.WriteString(statusPendingStyle.Render(fmt.Sprintf("  = unchanged %s (%d B)", c.Path, c.Size)) + "\n")
+			continue
+		}
+		for _, line := range strings.Split(c.Preview, "\n") {
+			b.WriteString(statusPendingStyle.Render("      "+line) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// renderResult shows the outcome of the last conversion. Generated files
+// inside the skill are listed relative to it.
+func renderResult(skillPath string, r *domain.ConversionResult) string {
+	if r.Message != "" {
+		// Nothing was converted; the message is shown as the skip reason
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nConverted %s → %s in %s\n", r.SourcePlatform, r.Target, r.Duration.Round(time.Millisecond)))
+	if len(r.Files) > 0 {
+		b.WriteString("Generated files:\n")
+		for _, f := range r.Files {
+			if rel, err := filepath.Rel(skillPath, f); err == nil && !strings.HasPrefix(rel, "..") {
+				f = rel
+			}
+			b.WriteString(statusSuccessStyle.Render("  + ") + f + "\n")
+		}
+	}
+	if len(r.MCPRewrites) > 0 {
+		b.WriteString("MCP rewrites:\n")
+		for _, rw := range r.MCPRewrites {
+			style := statusPendingStyle
+			if rw.Lossy {
+				style = statusWarningStyle
+			}
+			b.WriteString(style.Render("  ~ "+rw.String()) + "\n")
+		}
+	}
+	if len(r.Lossy) > 0 {
+		b.WriteString(fmt.Sprintf("Loss report: %d field(s) dropped\n", len(r.Lossy)))
+		for _, l := range r.Lossy {
+			b.WriteString(statusWarningStyle.Render("  - "+l.String()) + "\n")
+		}
+	}
+	b.WriteString(renderCommands(r.Commands))
+	if len(r.Settings) > 0 {
+		b.WriteString("Inferred settings:\n")
+		b.WriteString(renderSettings(r.Settings))
+	}
+	if len(r.MissingFiles) > 0 {
+		b.WriteString("Missing referenced files:\n")
+		for _, f := range r.MissingFiles {
+			b.WriteString(statusWarningStyle.Render("  ? "+f) + "\n")
+		}
+	}
+	if len(r.Warnings) > 0 {
+		b.WriteString("Warnings:\n")
+		for _, w := range r.Warnings {
+			b.WriteString(statusWarningStyle.Render("  ! "+w) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// commandPreviewLines bounds the prompt shown for each translated command.
+const commandPreviewLines = 8
+
+// renderCommands previews each translated command prompt, with the
+// constructs that did not translate cleanly.
+func renderCommands(commands []domain.CommandPreview) string {
+	if len(commands) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Command prompts:\n")
+	for _, c := range commands {
+		b.WriteString(fmt.Sprintf("  %s → %s\n", c.Source, c.Target))
+		lines := strings.Split(c.Prompt, "\n")
+		if len(lines) > commandPreviewLines {
+			lines = append(lines[:commandPreviewLines], fmt.Sprintf("… %d more line(s)", len(lines)-commandPreviewLines))
+		}
+		for _, line := range lines {
+			b.WriteString(statusPendingStyle.Render("      "+line) + "\n")
+		}
+		for _, n := range c.Notes {
+			b.WriteString(statusWarningStyle.Render("    ! "+n) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// renderRoundtrip shows the loss score of a round trip and what was lost.
+func renderRoundtrip(r *domain.RoundtripReport) string {
+	style := statusSuccessStyle
+	switch {
+	case r.Score >= 50:
+		style = statusFailStyle
+	case r.Score > 0:
+		style = statusRunningStyle
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nRound trip: %s → %s → %s, loss score ", r.From, r.Via, r.From))
+	b.WriteString(style.Render(fmt.Sprintf("%d/100", r.Score)) + "\n")
+	if len(r.Findings) == 0 {
+		b.WriteString(statusSuccessStyle.Render("  ✓ nothing lost") + "\n")
+	}
+	for _, f := range r.Findings {
+		b.WriteString("  - " + f.String() + "\n")
+	}
+	return b.String()
+}
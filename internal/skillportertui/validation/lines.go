@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// jsonLines maps dotted JSON paths (e.g. "mcpServers.db.command" or
+// "settings.0") to the 1-based line on which the key or element appears.
+// It expects data to be valid JSON.
+func jsonLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+
+		switch delim {
+		case '{':
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := keyTok.(string)
+				child := joinPath(path, key)
+				lines[child] = lineAt(data, int(dec.InputOffset()))
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for i := 0; dec.More(); i++ {
+				child := joinPath(path, strconv.Itoa(i))
+				lines[child] = lineAt(data, skipSeparators(data, int(dec.InputOffset())))
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		}
+		// Closing delimiter
+		_, err = dec.Token()
+		return err
+	}
+
+	_ = walk("")
+	return lines
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// lineAt returns the 1-based line containing byte offset off.
+func lineAt(data []byte, off int) int {
+	if off > len(data) {
+		off = len(data)
+	}
+	return bytes.Count(data[:off], []byte("\n")) + 1
+}
+
+// skipSeparators advances past whitespace and commas to the start of the next value.
+func skipSeparators(data []byte, off int) int {
+	for off < len(data) {
+		switch data[off] {
+		case ' ', '\t', '\r', '\n', ',':
+			off++
+		default:
+			return off
+		}
+	}
+	return off
+}
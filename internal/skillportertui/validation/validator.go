@@ -0,0 +1,320 @@
+// Package validation checks that skills and extensions meet platform
+// requirements. It is a port of Validator in src/analyzers/validator.js that
+// reports structured diagnostics instead of plain strings.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/conversion"
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+const (
+	skillFile       = "SKILL.md"
+	manifestFile    = "gemini-extension.json"
+	marketplaceFile = ".claude-plugin/marketplace.json"
+)
+
+var skillNameRe = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Validate checks dir against the rules for platform ("Claude", "Gemini" or
+// "Universal", case-insensitive) and returns every finding.
+func Validate(dir string, platform string) []domain.Diagnostic {
+	v := &validator{dir: dir}
+
+	isUniversal := strings.EqualFold(platform, "Universal")
+	if isUniversal || strings.EqualFold(platform, "Claude") {
+		v.validateClaude()
+	}
+	if isUniversal || strings.EqualFold(platform, "Gemini") {
+		v.validateGemini()
+	}
+
+	return v.diags
+}
+
+// HasErrors reports whether any diagnostic is an error.
+func HasErrors(diags []domain.Diagnostic) bool {
+	return Count(diags, domain.SeverityError) > 0
+}
+
+// Count returns the number of diagnostics with the given severity.
+func Count(diags []domain.Diagnostic, severity domain.Severity) int {
+	n := 0
+	for _, d := range diags {
+		if d.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+type validator struct {
+	dir   string
+	diags []domain.Diagnostic
+}
+
+func (v *validator) errorf(rule, file string, line int, format string, args ...any) {
+	v.add(rule, domain.SeverityError, file, line, format, args...)
+}
+
+func (v *validator) warnf(rule, file string, line int, format string, args ...any) {
+	v.add(rule, domain.SeverityWarning, file, line, format, args...)
+}
+
+func (v *validator) add(rule string, severity domain.Severity, file string, line int, format string, args ...any) {
+	v.diags = append(v.diags, domain.Diagnostic{
+		RuleID:   rule,
+		Severity: severity,
+		File:     file,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// readFile reads a file relative to the skill directory. Missing files return
+// (nil, false) without a diagnostic; other read errors are reported.
+func (v *validator) readFile(rel string) ([]byte, bool) {
+	data, err := os.ReadFile(filepath.Join(v.dir, filepath.FromSlash(rel)))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			v.errorf("io/read", rel, 0, "Validation failed: %v", err)
+		}
+		return nil, false
+	}
+	return data, true
+}
+
+func (v *validator) validateClaude() {
+	data, ok := v.readFile(skillFile)
+	if !ok {
+		if !v.exists(skillFile) {
+			v.errorf("claude/skill-missing", skillFile, 0, "Missing required file: SKILL.md")
+		}
+		return
+	}
+	content := string(data)
+
+	fm, err := conversion.ParseFrontmatter(data)
+	if errors.Is(err, conversion.ErrNoFrontmatter) {
+		v.errorf("claude/frontmatter-missing", skillFile, 1, "SKILL.md must have YAML frontmatter")
+		return
+	}
+	if err != nil {
+		v.errorf("claude/frontmatter-invalid", skillFile, 1, "SKILL.md frontmatter is not valid YAML: %v", err)
+		return
+	}
+	fields := fm.Fields()
+
+	// Frontmatter starts on line 2, after the opening ---
+	if name, ok := fields["name"]; !ok || name.Value == "" {
+		v.errorf("claude/name-required", skillFile, 1, "SKILL.md frontmatter missing required field: name")
+	} else {
+		line := name.Line + 1
+		if !skillNameRe.MatchString(name.Value) {
+			v.errorf("claude/name-format", skillFile, line, "Skill name must be lowercase letters, numbers, and hyphens only")
+		}
+		if utf8.RuneCountInString(name.Value) > 64 {
+			v.errorf("claude/name-length", skillFile, line, "Skill name must be 64 characters or less")
+		}
+	}
+
+	if desc, ok := fields["description"]; !ok || desc.Value == "" {
+		v.errorf("claude/description-required", skillFile, 1, "SKILL.md frontmatter missing required field: description")
+	} else {
+		line := desc.Line + 1
+		length := utf8.RuneCountInString(desc.Value)
+		if length > 1024 {
+			v.errorf("claude/description-length", skillFile, line, "Description must be 1024 characters or less")
+		}
+		if length < 50 {
+			v.warnf("claude/description-short", skillFile, line, "Description should be descriptive (at least 50 characters recommended)")
+		}
+	}
+
+	if !v.exists(marketplaceFile) {
+		v.warnf("claude/marketplace-missing", marketplaceFile, 0, "Missing .claude-plugin/marketplace.json (recommended for MCP server integration)")
+	} else {
+		v.validateMarketplace()
+	}
+
+	if idx := strings.Index(content, "\\"); idx >= 0 {
+		v.warnf("claude/backslash-path", skillFile, strings.Count(content[:idx], "\n")+1, "Use forward slashes (/) for file paths, not backslashes (\\)")
+	}
+}
+
+func (v *validator) validateGemini() {
+	data, ok := v.readFile(manifestFile)
+	if !ok {
+		if !v.exists(manifestFile) {
+			v.errorf("gemini/manifest-missing", manifestFile, 0, "Missing required file: gemini-extension.json")
+		}
+		return
+	}
+
+	var manifest map[string]any
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		v.errorf("gemini/manifest-json", manifestFile, jsonErrorLine(data, err), "Invalid JSON in gemini-extension.json: %v", err)
+		return
+	}
+	lines := jsonLines(data)
+
+	if name, ok := manifest["name"].(string); !ok || name == "" {
+		v.errorf("gemini/name-required", manifestFile, 0, "gemini-extension.json missing required field: name")
+	} else if dirName := filepath.Base(v.dir); name != dirName {
+		v.warnf("gemini/name-mismatch", manifestFile, lines["name"], "Extension name %q should match directory name %q", name, dirName)
+	}
+
+	if !truthy(manifest["version"]) {
+		v.errorf("gemini/version-required", manifestFile, 0, "gemini-extension.json missing required field: version")
+	}
+
+	if servers, ok := manifest["mcpServers"].(map[string]any); ok {
+		for _, serverName := range sortedKeys(servers) {
+			config, _ := servers[serverName].(map[string]any)
+			path := "mcpServers." + serverName
+
+			if !truthy(config["command"]) {
+				v.errorf("gemini/mcp-command-required", manifestFile, lines[path], "MCP server %q missing required field: command", serverName)
+			}
+
+			if args, ok := config["args"]; ok && truthy(args) {
+				argsJSON, _ := json.Marshal(args)
+				argsStr := string(argsJSON)
+				if strings.Contains(argsStr, "mcp-server") && !strings.Contains(argsStr, "${extensionPath}") {
+					v.warnf("gemini/mcp-extension-path", manifestFile, lines[path+".args"], "MCP server %q should use ${extensionPath} variable for paths", serverName)
+				}
+			}
+		}
+	}
+
+	if rawSettings, ok := manifest["settings"]; ok && rawSettings != nil {
+		settings, isArray := rawSettings.([]any)
+		if !isArray {
+			v.errorf("gemini/settings-array", manifestFile, lines["settings"], "settings must be an array")
+		} else {
+			for i, raw := range settings {
+				setting, _ := raw.(map[string]any)
+				line := lines[fmt.Sprintf("settings.%d", i)]
+				name, _ := setting["name"].(string)
+				if name == "" {
+					v.errorf("gemini/setting-name-required", manifestFile, line, "Setting at index %d missing required field: name", i)
+				}
+				if !truthy(setting["description"]) {
+					label := name
+					if label == "" {
+						label = fmt.Sprintf("#%d", i)
+					}
+					v.warnf("gemini/setting-description", manifestFile, line, "Setting %q should have a description", label)
+				}
+			}
+		}
+	}
+
+	contextFileName, _ := manifest["contextFileName"].(string)
+	if contextFileName == "" {
+		contextFileName = "GEMINI.md"
+	}
+	if !v.exists(contextFileName) {
+		v.warnf("gemini/context-missing", contextFileName, 0, "Missing context file: %s (recommended for providing context to Gemini)", contextFileName)
+	}
+
+	if excludeTools, ok := manifest["excludeTools"]; ok && excludeTools != nil {
+		if _, isArray := excludeTools.([]any); !isArray {
+			v.errorf("gemini/exclude-tools-array", manifestFile, lines["excludeTools"], "excludeTools must be an array")
+		}
+	}
+}
+
+func (v *validator) validateMarketplace() {
+	data, ok := v.readFile(marketplaceFile)
+	if !ok {
+		return
+	}
+
+	var marketplace map[string]any
+	if err := json.Unmarshal(data, &marketplace); err != nil {
+		v.errorf("marketplace/json", marketplaceFile, jsonErrorLine(data, err), "Invalid JSON in marketplace.json: %v", err)
+		return
+	}
+	lines := jsonLines(data)
+
+	if !truthy(marketplace["name"]) {
+		v.errorf("marketplace/name-required", marketplaceFile, 0, "marketplace.json missing required field: name")
+	}
+
+	if metadata, ok := marketplace["metadata"].(map[string]any); !ok {
+		v.errorf("marketplace/metadata-required", marketplaceFile, 0, "marketplace.json missing required field: metadata")
+	} else {
+		if !truthy(metadata["description"]) {
+			v.warnf("marketplace/metadata-description", marketplaceFile, lines["metadata"], "marketplace.json metadata should include description")
+		}
+		if !truthy(metadata["version"]) {
+			v.warnf("marketplace/metadata-version", marketplaceFile, lines["metadata"], "marketplace.json metadata should include version")
+		}
+	}
+
+	plugins, ok := marketplace["plugins"].([]any)
+	if !ok {
+		v.errorf("marketplace/plugins-required", marketplaceFile, lines["plugins"], "marketplace.json missing required field: plugins (array)")
+		return
+	}
+	for i, raw := range plugins {
+		plugin, _ := raw.(map[string]any)
+		line := lines[fmt.Sprintf("plugins.%d", i)]
+		if !truthy(plugin["name"]) {
+			v.errorf("marketplace/plugin-name-required", marketplaceFile, line, "Plugin at index %d missing required field: name", i)
+		}
+		if !truthy(plugin["description"]) {
+			v.errorf("marketplace/plugin-description-required", marketplaceFile, line, "Plugin at index %d missing required field: description", i)
+		}
+	}
+}
+
+func (v *validator) exists(rel string) bool {
+	info, err := os.Stat(filepath.Join(v.dir, filepath.FromSlash(rel)))
+	return err == nil && !info.IsDir()
+}
+
+// jsonErrorLine returns the line of a JSON syntax error, or 0 if unknown.
+func jsonErrorLine(data []byte, err error) int {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return lineAt(data, int(syntaxErr.Offset))
+	}
+	return 0
+}
+
+// truthy mirrors JavaScript truthiness for values decoded from JSON.
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return t
+	case string:
+		return t != ""
+	case float64:
+		return t != 0
+	default:
+		return true
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jduncan-rva/skill-porter/internal/skillportertui/domain"
+)
+
+func findRule(diags []domain.Diagnostic, rule string) *domain.Diagnostic {
+	for i := range diags {
+		if diags[i].RuleID == rule {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+func TestValidate_Fixtures(t *testing.T) {
+	for _, name := range []string{"api-connector-converted", "code-formatter-converted"} {
+		dir := filepath.Join("../../../examples/before-after", name)
+		diags := Validate(dir, "Universal")
+		if HasErrors(diags) {
+			t.Errorf("%s: expected no errors, got %v", name, diags)
+		}
+	}
+}
+
+func TestValidate_ClaudeRules(t *testing.T) {
+	dir := t.TempDir()
+	skill := "---\nname: Bad_Name\ndescription: short\n---\n\nSee C:\\path\n"
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skill), 0644)
+
+	diags := Validate(dir, "Claude")
+
+	tests := []struct {
+		rule     string
+		severity domain.Severity
+		line     int
+	}{
+		{"claude/name-format", domain.SeverityError, 2},
+		{"claude/description-short", domain.SeverityWarning, 3},
+		{"claude/marketplace-missing", domain.SeverityWarning, 0},
+		{"claude/backslash-path", domain.SeverityWarning, 6},
+	}
+	for _, tt := range tests {
+		d := findRule(diags, tt.rule)
+		if d == nil {
+			t.Errorf("Expected diagnostic %s, got %v", tt.rule, diags)
+			continue
+		}
+		if d.Severity != tt.severity || d.Line != tt.line {
+			t.Errorf("%s: got severity %s line %d, want %s line %d", tt.rule, d.Severity, d.Line, tt.severity, tt.line)
+		}
+	}
+}
+
+func TestValidate_MissingFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# Title\n"), 0644)
+
+	diags := Validate(dir, "Claude")
+	if findRule(diags, "claude/frontmatter-missing") == nil {
+		t.Errorf("Expected claude/frontmatter-missing, got %v", diags)
+	}
+}
+
+func TestValidate_CRLFFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	skill := "\ufeff---\r\nname: Bad_Name\r\ndescription: A demo skill with a sufficiently long description text\r\n---\r\n"
+	os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(skill), 0644)
+
+	diags := Validate(dir, "Claude")
+	if d := findRule(diags, "claude/frontmatter-missing"); d != nil {
+		t.Errorf("Expected CRLF frontmatter with a BOM to be read, got %v", diags)
+	}
+	if d := findRule(diags, "claude/name-format"); d == nil || d.Line != 2 {
+		t.Errorf("Expected claude/name-format on line 2, got %v", diags)
+	}
+}
+
+func TestValidate_GeminiRules(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-ext")
+	os.Mkdir(dir, 0755)
+	manifest := `{
+  "name": "other-name",
+  "mcpServers": {
+    "db": {
+      "args": ["mcp-server/index.js"]
+    }
+  },
+  "settings": [
+    {"description": "no name"},
+    {"name": "TOKEN"}
+  ],
+  "excludeTools": "Bash"
+}`
+	os.WriteFile(filepath.Join(dir, "gemini-extension.json"), []byte(manifest), 0644)
+
+	diags := Validate(dir, "Gemini")
+
+	tests := []struct {
+		rule string
+		line int
+	}{
+		{"gemini/name-mismatch", 2},
+		{"gemini/version-required", 0},
+		{"gemini/mcp-command-required", 4},
+		{"gemini/mcp-extension-path", 5},
+		{"gemini/setting-name-required", 9},
+		{"gemini/setting-description", 10},
+		{"gemini/context-missing", 0},
+		{"gemini/exclude-tools-array", 12},
+	}
+	for _, tt := range tests {
+		d := findRule(diags, tt.rule)
+		if d == nil {
+			t.Errorf("Expected diagnostic %s, got %v", tt.rule, diags)
+			continue
+		}
+		if d.Line != tt.line {
+			t.Errorf("%s: got line %d, want %d", tt.rule, d.Line, tt.line)
+		}
+	}
+}
+
+func TestValidate_InvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "gemini-extension.json"), []byte("{\n  \"name\": \"x\",\n}"), 0644)
+
+	diags := Validate(dir, "Gemini")
+	d := findRule(diags, "gemini/manifest-json")
+	if d == nil {
+		t.Fatalf("Expected gemini/manifest-json, got %v", diags)
+	}
+	if d.Line != 3 {
+		t.Errorf("Expected syntax error on line 3, got %d", d.Line)
+	}
+}
+
+func TestValidate_Marketplace(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "SKILL.md"),
+		[]byte("---\nname: demo\ndescription: A demo skill with a sufficiently long description text\n---\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, ".claude-plugin"), 0755)
+	os.WriteFile(filepath.Join(dir, ".claude-plugin", "marketplace.json"),
+		[]byte(`{"name": "m", "metadata": {}, "plugins": [{"name": "p"}]}`), 0644)
+
+	diags := Validate(dir, "Claude")
+	for _, rule := range []string{"marketplace/metadata-description", "marketplace/metadata-version", "marketplace/plugin-description-required"} {
+		if findRule(diags, rule) == nil {
+			t.Errorf("Expected diagnostic %s, got %v", rule, diags)
+		}
+	}
+	if Count(diags, domain.SeverityError) != 1 {
+		t.Errorf("Expected 1 error, got %v", diags)
+	}
+}
@@ -19,6 +19,26 @@ const porter = new SkillPorter();
 const packagePath = new URL('../package.json', import.meta.url);
 const packageData = JSON.parse(await fs.readFile(packagePath, 'utf8'));
 
+/**
+ * Print a conversion result as JSON, exiting non-zero when it failed
+ * @param {object} result - Result from SkillPorter.convert or makeUniversal
+ */
+function printJSON(result) {
+  console.log(JSON.stringify({
+    success: result.success,
+    sourcePlatform: result.sourcePlatform,
+    platform: result.platform,
+    message: result.message,
+    files: result.files || [],
+    warnings: result.warnings || [],
+    errors: result.errors || [],
+    validation: result.validation
+  }, null, 2));
+  if (!result.success) {
+    process.exit(1);
+  }
+}
+
 program
   .name('skill-porter')
   .description('Universal tool to convert Claude Code skills to Gemini CLI extensions and vice versa')
@@ -31,9 +51,12 @@ program
   .option('-t, --to <platform>', 'Target platform (claude or gemini)', 'gemini')
   .option('-o, --output <path>', 'Output directory path')
   .option('--no-validate', 'Skip validation after conversion')
+  .option('--json', 'Print the result as JSON for other tools')
   .action(async (sourcePath, options) => {
     try {
-      console.log(chalk.blue('\n🔄 Converting skill/extension...\n'));
+      if (!options.json) {
+        console.log(chalk.blue('\n🔄 Converting skill/extension...\n'));
+      }
 
       const result = await porter.convert(
         path.resolve(sourcePath),
@@ -44,6 +67,11 @@ program
         }
       );
 
+      if (options.json) {
+        printJSON(result);
+        return;
+      }
+
       if (result.success) {
         console.log(chalk.green('✓ Conversion successful!\n'));
 
@@ -211,9 +239,12 @@ program
   .command('universal <source-path>')
   .description('Make a skill/extension work on both platforms')
   .option('-o, --output <path>', 'Output directory path')
+  .option('--json', 'Print the result as JSON for other tools')
   .action(async (sourcePath, options) => {
     try {
-      console.log(chalk.blue('\n🌐 Creating universal skill/extension...\n'));
+      if (!options.json) {
+        console.log(chalk.blue('\n🌐 Creating universal skill/extension...\n'));
+      }
 
       const result = await porter.makeUniversal(
         path.resolve(sourcePath),
@@ -222,6 +253,11 @@ program
         }
       );
 
+      if (options.json) {
+        printJSON(result);
+        return;
+      }
+
       if (result.success) {
         console.log(chalk.green('✓ Successfully created universal skill/extension!\n'));
         console.log(chalk.gray('Your skill/extension now works with both Claude Code and Gemini CLI.\n'));
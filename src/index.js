@@ -45,7 +45,8 @@ export class SkillPorter {
       return {
         success: true,
         message: 'Already a universal skill/extension - no conversion needed',
-        platform: PLATFORM_TYPES.UNIVERSAL
+        platform: PLATFORM_TYPES.UNIVERSAL,
+        sourcePlatform: detection.platform
       };
     }
 
@@ -53,7 +54,8 @@ export class SkillPorter {
       return {
         success: true,
         message: `Already a ${targetPlatform} ${targetPlatform === 'claude' ? 'skill' : 'extension'} - no conversion needed`,
-        platform: detection.platform
+        platform: detection.platform,
+        sourcePlatform: detection.platform
       };
     }
 
@@ -70,6 +72,7 @@ export class SkillPorter {
     } else {
       throw new Error(`Invalid target platform: ${targetPlatform}. Must be 'claude' or 'gemini'`);
     }
+    result.sourcePlatform = detection.platform;
 
     // Step 4: Validate if requested
     if (validate && result.success) {
@@ -118,7 +121,8 @@ export class SkillPorter {
       return {
         success: true,
         message: 'Already a universal skill/extension',
-        platform: PLATFORM_TYPES.UNIVERSAL
+        platform: PLATFORM_TYPES.UNIVERSAL,
+        sourcePlatform: detection.platform
       };
     }
 